@@ -0,0 +1,166 @@
+/*
+This Area package is intended to make working with Areas less error prone because (1) all Area objects are immutable and (2)
+the unit is always required and always accounted for.
+
+Area is the two dimensional counterpart to Distance and shares its design.
+*/
+package area
+
+import (
+	"math"
+	"sort"
+	dist "stellarsunset/spherical/distance"
+)
+
+type Area struct {
+	amount float64
+	unit   Unit
+}
+
+func Zero() *Area {
+	return &Area{0., SquareMeters}
+}
+
+func Of(amount float64, unit Unit) *Area {
+	return &Area{amount, unit}
+}
+
+func OfSquareNauticalMiles(amount float64) *Area {
+	return &Area{amount, SquareNauticalMiles}
+}
+
+func OfSquareFeet(amount float64) *Area {
+	return &Area{amount, SquareFeet}
+}
+
+func OfSquareMeters(amount float64) *Area {
+	return &Area{amount, SquareMeters}
+}
+
+func OfSquareKilometers(amount float64) *Area {
+	return &Area{amount, SquareKilometers}
+}
+
+func OfSquareMiles(amount float64) *Area {
+	return &Area{amount, SquareMiles}
+}
+
+// The Area of a rectangle with the provided side lengths
+func OfRectangle(one, two *dist.Distance) *Area {
+	return OfSquareMeters(one.InMeters() * two.InMeters())
+}
+
+// The Unit this area was originally defined with
+func (this *Area) NativeUnit() Unit {
+	return this.unit
+}
+
+func (this *Area) In(desiredUnit Unit) float64 {
+	if this.unit == desiredUnit {
+		return this.amount
+	} else {
+		return this.amount * (UnitsPerSquareMeter(desiredUnit) / UnitsPerSquareMeter(this.unit))
+	}
+}
+
+func (this *Area) InSquareNauticalMiles() float64 {
+	return this.In(SquareNauticalMiles)
+}
+
+func (this *Area) InSquareFeet() float64 {
+	return this.In(SquareFeet)
+}
+
+func (this *Area) InSquareMeters() float64 {
+	return this.In(SquareMeters)
+}
+
+func (this *Area) InSquareKilometers() float64 {
+	return this.In(SquareKilometers)
+}
+
+func (this *Area) InSquareMiles() float64 {
+	return this.In(SquareMiles)
+}
+
+func (this *Area) Negate() *Area {
+	return Of(-this.amount, this.unit)
+}
+
+func (this *Area) Abs() *Area {
+	return Of(math.Abs(this.amount), this.unit)
+}
+
+func (this *Area) IsPositive() bool {
+	return this.amount > 0.
+}
+
+func (this *Area) IsNegative() bool {
+	return this.amount < 0.
+}
+
+func (this *Area) IsZero() bool {
+	return this.amount == 0.
+}
+
+func (this *Area) Times(scalar float64) *Area {
+	return Of(this.amount*scalar, this.unit)
+}
+
+func (this *Area) Plus(that *Area) *Area {
+	return Of(this.amount+that.In(this.unit), this.unit)
+}
+
+func (this *Area) Minus(that *Area) *Area {
+	return Of(this.amount-that.In(this.unit), this.unit)
+}
+
+func (this *Area) IsLessThan(that *Area) bool {
+	return this.amount < that.In(this.unit)
+}
+
+func (this *Area) IsLessThanOrEqualTo(that *Area) bool {
+	return this.amount <= that.In(this.unit)
+}
+
+func (this *Area) IsGreaterThan(that *Area) bool {
+	return this.amount > that.In(this.unit)
+}
+
+func (this *Area) IsGreaterThanOrEqualTo(that *Area) bool {
+	return this.amount >= that.In(this.unit)
+}
+
+type byAmount []Area
+
+func (a byAmount) Len() int {
+	return len(a)
+}
+
+func (a byAmount) Swap(i, j int) {
+	a[i], a[j] = a[j], a[i]
+}
+
+func (a byAmount) Less(i, j int) bool {
+	return a[i].IsLessThan(&a[j])
+}
+
+// Sort the provided slice of areas based on their unit-aligned amounts
+func Sort(areas []Area) {
+	sort.Sort(byAmount(areas))
+}
+
+func Sum(areas []Area) *Area {
+	switch l := len(areas); l {
+	case 0:
+		return Zero()
+	case 1:
+		return &areas[0]
+	default:
+		amount, unit := areas[0].amount, areas[0].unit
+		for i := 1; i < l; i++ {
+			amount += areas[i].In(unit)
+		}
+		return Of(amount, unit)
+	}
+}
@@ -0,0 +1,80 @@
+package area_test
+
+import (
+	"math"
+	"stellarsunset/spherical/area"
+	dist "stellarsunset/spherical/distance"
+	"testing"
+)
+
+func isTrue(t *testing.T, condition bool, s string) {
+	if !condition {
+		t.Error(s)
+	}
+}
+
+func isEqual(t *testing.T, expected, actual area.Area) {
+	if expected != actual {
+		t.Errorf("want = %+v, got = %+v", expected, actual)
+	}
+}
+
+func withinFractionOfExpected(t *testing.T, expected, actual, percentError float64, s string) {
+	if math.Abs(expected-actual) > (percentError * math.Abs(expected)) {
+		t.Errorf("%s: want = %f, got = %f, tol = %f", s, expected, actual, percentError)
+	}
+}
+
+func TestIn(t *testing.T) {
+
+	tol, oneNm := .00001, area.OfSquareNauticalMiles(1.)
+
+	withinFractionOfExpected(t, 1852.*1852., oneNm.InSquareMeters(), tol, "InSquareMeters()")
+	withinFractionOfExpected(t, 3.429904, oneNm.InSquareKilometers(), tol, "InSquareKilometers()")
+	withinFractionOfExpected(t, 36919179.4, oneNm.InSquareFeet(), tol, "InSquareFeet()")
+	withinFractionOfExpected(t, 1.324293, oneNm.InSquareMiles(), tol, "InSquareMiles()")
+}
+
+func TestOfRectangle(t *testing.T) {
+
+	a := area.OfRectangle(dist.OfKilometers(2.), dist.OfMeters(500.))
+	withinFractionOfExpected(t, 1., a.InSquareKilometers(), 1e-12, "OfRectangle()")
+}
+
+func TestArithmetic(t *testing.T) {
+
+	oneKm, halfKm := area.OfSquareKilometers(1.), area.OfSquareMeters(500000.)
+
+	withinFractionOfExpected(t, 1.5, oneKm.Plus(halfKm).InSquareKilometers(), 1e-12, "Plus()")
+	withinFractionOfExpected(t, .5, oneKm.Minus(halfKm).InSquareKilometers(), 1e-12, "Minus()")
+	withinFractionOfExpected(t, 2., oneKm.Times(2.).InSquareKilometers(), 1e-12, "Times()")
+
+	isEqual(t, *area.OfSquareKilometers(-1.), *oneKm.Negate())
+	isEqual(t, *oneKm, *oneKm.Negate().Abs())
+	isTrue(t, oneKm.Negate().IsNegative(), "IsNegative()")
+	isTrue(t, oneKm.IsPositive(), "IsPositive()")
+	isTrue(t, area.Zero().IsZero(), "IsZero()")
+}
+
+func TestComparisonMethods(t *testing.T) {
+
+	oneKm, oneMillionMeters, oneMeter := area.OfSquareKilometers(1.), area.OfSquareMeters(1e6), area.OfSquareMeters(1.)
+
+	isTrue(t, oneMeter.IsLessThan(oneKm), "1m² < 1km²")
+	isTrue(t, oneKm.IsGreaterThan(oneMeter), "1km² > 1m²")
+	isTrue(t, oneKm.IsLessThanOrEqualTo(oneMillionMeters), "1km² <= 1e6m²")
+	isTrue(t, oneKm.IsGreaterThanOrEqualTo(oneMillionMeters), "1km² >= 1e6m²")
+}
+
+func TestSortAndSum(t *testing.T) {
+
+	areas := []area.Area{*area.OfSquareKilometers(1.), *area.OfSquareMeters(1.), *area.OfSquareNauticalMiles(1.)}
+
+	area.Sort(areas)
+	isEqual(t, *area.OfSquareMeters(1.), areas[0])
+	isEqual(t, *area.OfSquareKilometers(1.), areas[1])
+	isEqual(t, *area.OfSquareNauticalMiles(1.), areas[2])
+
+	withinFractionOfExpected(t, 1e6+1.+1852.*1852., area.Sum(areas).InSquareMeters(), 1e-12, "Sum()")
+	isEqual(t, *area.Zero(), *area.Sum(nil))
+}
@@ -0,0 +1,32 @@
+package area
+
+type Unit int
+
+const (
+	SquareNauticalMiles Unit = iota
+	SquareFeet
+	SquareMeters
+	SquareKilometers
+	SquareMiles
+)
+
+type info struct {
+	perSquareMeter float64
+	abbr           string
+}
+
+var units = [...]info{
+	SquareNauticalMiles: {perSquareMeter: 1. / (1852. * 1852.), abbr: "NM²"},
+	SquareFeet:          {perSquareMeter: 1. / (.3048 * .3048), abbr: "ft²"},
+	SquareMeters:        {perSquareMeter: 1., abbr: "m²"},
+	SquareKilometers:    {perSquareMeter: 1e-6, abbr: "km²"},
+	SquareMiles:         {perSquareMeter: 1. / (.3048 * 5280. * .3048 * 5280.), abbr: "mi²"},
+}
+
+func UnitsPerSquareMeter(unit Unit) float64 {
+	return units[unit].perSquareMeter
+}
+
+func Abbr(unit Unit) string {
+	return units[unit].abbr
+}
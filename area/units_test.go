@@ -0,0 +1,22 @@
+package area_test
+
+import (
+	"stellarsunset/spherical/area"
+	"testing"
+)
+
+func TestSquareMetersPerUnit(t *testing.T) {
+
+	want := 1.
+	if got := area.UnitsPerSquareMeter(area.SquareMeters); got != want {
+		t.Errorf("UnitsPerSquareMeter(SquareMeters) = %f, want %f", got, want)
+	}
+}
+
+func TestAbbreviation(t *testing.T) {
+
+	want := area.SquareNauticalMiles
+	if got := area.OfSquareNauticalMiles(1.).NativeUnit(); got != want {
+		t.Errorf("OfSquareNauticalMiles(1.) = %q, want %q", area.Abbr(got), area.Abbr(want))
+	}
+}
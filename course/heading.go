@@ -0,0 +1,63 @@
+package course
+
+import (
+	"math"
+)
+
+// A Heading is the direction an aircraft or vessel is POINTING, as opposed to the Course it is moving along. The two only differ
+// when it is affected by the wind (or current), see the wind triangle functions.
+type Heading struct {
+	angle float64
+	unit  Unit
+}
+
+func HeadingOf(angle float64, unit Unit) *Heading {
+	return &Heading{angle, unit}
+}
+
+func HeadingOfDegrees(angle float64) *Heading {
+	return &Heading{angle, Degrees}
+}
+
+func HeadingOfRadians(angle float64) *Heading {
+	return &Heading{angle, Radians}
+}
+
+func (this *Heading) NativeUnit() Unit {
+	return this.unit
+}
+
+func (this *Heading) In(desiredUnit Unit) float64 {
+	if this.unit == desiredUnit {
+		return this.angle
+	} else {
+		return this.angle * (UnitsPerDegree(desiredUnit) / UnitsPerDegree(this.unit))
+	}
+}
+
+func (this *Heading) InDegrees() float64 {
+	return this.In(Degrees)
+}
+
+func (this *Heading) InRadians() float64 {
+	return this.In(Radians)
+}
+
+func (this *Heading) Sin() float64 {
+	return math.Sin(this.InRadians())
+}
+
+func (this *Heading) Cos() float64 {
+	return math.Cos(this.InRadians())
+}
+
+// The course flown when pointing along this heading in still air, e.g. a helicopter flying the direction it's pointed
+func (this *Heading) InStillAir() *Course {
+	return Of(this.angle, this.unit)
+}
+
+// The angle from the course to this heading (in degrees), positive when the heading is clockwise of it, i.e. the wind correction
+// angle when flying the course with this heading
+func (this *Heading) AngleFrom(course *Course) float64 {
+	return AngleDifference(this.InDegrees(), course.InDegrees())
+}
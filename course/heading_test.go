@@ -0,0 +1,27 @@
+package course_test
+
+import (
+	crs "stellarsunset/spherical/course"
+	"testing"
+)
+
+func TestHeadingUnits(t *testing.T) {
+
+	heading := crs.HeadingOfDegrees(90.)
+	withinError(t, 1.5707963267948966, heading.InRadians(), "InRadians()")
+	withinError(t, 1., heading.Sin(), "Sin()")
+	withinError(t, 0., heading.Cos(), "Cos()")
+	isEqual(t, crs.Degrees, heading.NativeUnit())
+
+	withinError(t, 180., crs.HeadingOfRadians(3.141592653589793).InDegrees(), "InDegrees()")
+	withinError(t, 45., crs.HeadingOf(45., crs.Degrees).InDegrees(), "HeadingOf()")
+}
+
+func TestHeadingCourse(t *testing.T) {
+
+	withinError(t, 270., crs.HeadingOfDegrees(270.).InStillAir().InDegrees(), "InStillAir()")
+
+	// Headings clockwise of the course have positive angles, including across north
+	withinError(t, 10., crs.HeadingOfDegrees(5.).AngleFrom(crs.OfDegrees(355.)), "Clockwise")
+	withinError(t, -10., crs.HeadingOfDegrees(355.).AngleFrom(crs.OfDegrees(5.)), "Anticlockwise")
+}
@@ -0,0 +1,95 @@
+package course
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	spd "stellarsunset/spherical/speed"
+)
+
+// Returned when the wind is too strong for the airspeed to hold the desired course
+var ErrWindTooStrong = errors.New("Wind is too strong to hold course")
+
+// A Wind is described by the direction it is blowing FROM (as reported in e.g. METARs and winds aloft forecasts) and its speed
+type Wind struct {
+	from  *Course
+	speed *spd.Speed
+}
+
+func NewWind(from *Course, speed *spd.Speed) *Wind {
+	return &Wind{from, speed}
+}
+
+func Calm() *Wind {
+	return &Wind{North(), spd.Zero()}
+}
+
+// The direction the wind is blowing from
+func (this *Wind) From() *Course {
+	return this.from
+}
+
+func (this *Wind) Speed() *spd.Speed {
+	return this.speed
+}
+
+// The components of the wind along and across the course in knots, the headwind is negative for a tailwind and the crosswind is
+// positive when blowing from the right
+func (this *Wind) Components(course *Course) (headwind, crosswind float64) {
+	angle := (this.from.InDegrees() - course.InDegrees()) * math.Pi / 180.
+	return this.speed.InKnots() * math.Cos(angle), this.speed.InKnots() * math.Sin(angle)
+}
+
+// Solves the wind triangle for the heading to fly at the true airspeed to hold the course in the wind, along with the resulting
+// ground speed. Returns an error matching ErrWindTooStrong if the crosswind exceeds the airspeed or the headwind leaves no
+// ground speed.
+func HeadingFor(course *Course, trueAirspeed *spd.Speed, wind *Wind) (*Heading, *spd.Speed, error) {
+
+	tas := trueAirspeed.InKnots()
+	headwind, crosswind := wind.Components(course)
+
+	if math.Abs(crosswind) > tas {
+		return nil, nil, fmt.Errorf("%w: crosswind of %f knots exceeds airspeed of %f knots", ErrWindTooStrong, crosswind, tas)
+	}
+
+	// Turning into the wind by the correction angle cancels the crosswind, the remaining airspeed less the headwind is left
+	correction := math.Asin(crosswind / tas)
+	groundSpeed := tas*math.Cos(correction) - headwind
+	if groundSpeed <= 0. {
+		return nil, nil, fmt.Errorf("%w: headwind of %f knots leaves no ground speed", ErrWindTooStrong, headwind)
+	}
+
+	heading := HeadingOfDegrees(normalize(course.InDegrees() + correction*180./math.Pi))
+	return heading, spd.OfKnots(groundSpeed), nil
+}
+
+// Solves the wind triangle for the course and ground speed resulting from flying the heading at the true airspeed in the wind
+func CourseFor(heading *Heading, trueAirspeed *spd.Speed, wind *Wind) (*Course, *spd.Speed) {
+
+	// Sum the air and wind velocities (north, east), the wind blowing towards the opposite of its direction
+	tas, w := trueAirspeed.InKnots(), wind.speed.InKnots()
+	north := tas*heading.Cos() - w*wind.from.Cos()
+	east := tas*heading.Sin() - w*wind.from.Sin()
+
+	return OfDegrees(normalize(math.Atan2(east, north) * 180. / math.Pi)), spd.OfKnots(math.Hypot(north, east))
+}
+
+// Recovers the wind from the heading and true airspeed of an aircraft along with the course and ground speed it was observed
+// making good, e.g. to estimate winds aloft from surveillance data. This is the difference between the ground and air velocities.
+func WindFrom(heading *Heading, trueAirspeed *spd.Speed, course *Course, groundSpeed *spd.Speed) *Wind {
+
+	tas, gs := trueAirspeed.InKnots(), groundSpeed.InKnots()
+	north := gs*course.Cos() - tas*heading.Cos()
+	east := gs*course.Sin() - tas*heading.Sin()
+
+	if north == 0. && east == 0. {
+		return Calm()
+	}
+	// The wind blows towards (north, east) so comes from the opposite direction
+	return NewWind(OfDegrees(normalize(math.Atan2(-east, -north)*180./math.Pi)), spd.OfKnots(math.Hypot(north, east)))
+}
+
+// Normalizes the angle (in degrees) to [0, 360)
+func normalize(degrees float64) float64 {
+	return math.Mod(math.Mod(degrees, 360.)+360., 360.)
+}
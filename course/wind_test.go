@@ -0,0 +1,83 @@
+package course_test
+
+import (
+	"errors"
+	"math"
+	crs "stellarsunset/spherical/course"
+	spd "stellarsunset/spherical/speed"
+	"testing"
+)
+
+func TestWindComponents(t *testing.T) {
+
+	wind := crs.NewWind(crs.OfDegrees(270.), spd.OfKnots(20.))
+
+	headwind, crosswind := wind.Components(crs.West())
+	withinError(t, 20., headwind, "Headwind")
+	withinError(t, 0., crosswind, "Crosswind")
+
+	// Flying north a westerly wind blows from the left
+	headwind, crosswind = wind.Components(crs.North())
+	withinError(t, 0., headwind, "Headwind")
+	withinError(t, -20., crosswind, "Crosswind")
+}
+
+func TestHeadingFor(t *testing.T) {
+
+	// A 30 knot wind from the right at 90 degrees to the course on a 120 knot aircraft
+	heading, groundSpeed, err := crs.HeadingFor(crs.North(), spd.OfKnots(120.), crs.NewWind(crs.East(), spd.OfKnots(30.)))
+	isTrue(t, err == nil, "Error")
+
+	correction := math.Asin(30./120.) * 180. / math.Pi
+	withinError(t, correction, heading.InDegrees(), "Heading")
+	withinError(t, math.Sqrt(120.*120.-30.*30.), groundSpeed.InKnots(), "Ground speed")
+
+	// Headwinds and tailwinds only change the ground speed
+	tailwind := crs.NewWind(crs.OfDegrees(315.), spd.OfKnots(100.))
+	heading, groundSpeed, _ = crs.HeadingFor(crs.OfDegrees(135.), spd.OfKnots(450.), tailwind)
+	withinError(t, 135., heading.InDegrees(), "Heading")
+	withinError(t, 550., groundSpeed.InKnots(), "Tailwind")
+
+	// Corrections to the left wrap across north
+	heading, _, _ = crs.HeadingFor(crs.OfDegrees(5.), spd.OfKnots(100.), crs.NewWind(crs.West(), spd.OfKnots(50.)))
+	withinError(t, 5.-math.Asin(50.*math.Cos(5.*math.Pi/180.)/100.)*180./math.Pi+360., heading.InDegrees(), "Wrapped")
+
+	calm, groundSpeed, _ := crs.HeadingFor(crs.OfDegrees(200.), spd.OfKnots(100.), crs.Calm())
+	withinError(t, 200., calm.InDegrees(), "Calm")
+	withinError(t, 100., groundSpeed.InKnots(), "Calm")
+}
+
+func TestHeadingForWindTooStrong(t *testing.T) {
+
+	_, _, err := crs.HeadingFor(crs.North(), spd.OfKnots(40.), crs.NewWind(crs.East(), spd.OfKnots(50.)))
+	isTrue(t, errors.Is(err, crs.ErrWindTooStrong), "Crosswind")
+
+	_, _, err = crs.HeadingFor(crs.North(), spd.OfKnots(40.), crs.NewWind(crs.North(), spd.OfKnots(50.)))
+	isTrue(t, errors.Is(err, crs.ErrWindTooStrong), "Headwind")
+}
+
+func TestWindTriangleRoundTrip(t *testing.T) {
+
+	tas := spd.OfKnots(250.)
+	for c := 0.; c < 360.; c += 30. {
+		for w := 0.; w < 360.; w += 45. {
+			wind := crs.NewWind(crs.OfDegrees(w), spd.OfKnots(60.))
+
+			heading, groundSpeed, err := crs.HeadingFor(crs.OfDegrees(c), tas, wind)
+			isTrue(t, err == nil, "Error")
+
+			// Flying the heading makes good the course
+			course, speed := crs.CourseFor(heading, tas, wind)
+			withinError(t, 0., crs.AngleDifference(c, course.InDegrees()), "Course")
+			withinError(t, groundSpeed.InKnots(), speed.InKnots(), "Ground speed")
+
+			// And the wind is recovered from the observed course and ground speed
+			recovered := crs.WindFrom(heading, tas, course, speed)
+			withinError(t, 0., crs.AngleDifference(w, recovered.From().InDegrees()), "Wind direction")
+			withinError(t, 60., recovered.Speed().InKnots(), "Wind speed")
+		}
+	}
+
+	calm := crs.WindFrom(crs.HeadingOfDegrees(90.), tas, crs.East(), tas)
+	isTrue(t, calm.Speed().IsZero(), "Calm")
+}
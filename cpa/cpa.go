@@ -0,0 +1,178 @@
+/*
+This CPA package computes the closest point of approach (CPA) between two moving objects, e.g. for conflict alerting between
+aircraft or collision avoidance between vessels.
+
+Objects are moved along Great Circles rather than straight lines on a flat Earth, so the results remain correct over long look
+ahead times and at high latitudes where meridians converge.
+*/
+package cpa
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	crs "stellarsunset/spherical/course"
+	dist "stellarsunset/spherical/distance"
+	ll "stellarsunset/spherical/latlong"
+	spd "stellarsunset/spherical/speed"
+	"stellarsunset/spherical/track"
+	"time"
+)
+
+const (
+	// Number of evenly spaced times checked before refining the closest, enough to find the right minimum when the separation has
+	// more than one (e.g. objects circling the globe or following multiple legs)
+	samples int = 32
+	// The precision (in seconds) to which the time of closest approach is refined
+	timeTolerance float64 = 1e-3
+)
+
+// Returned when two tracks have no times in common
+var ErrNoOverlap = errors.New("Tracks do not overlap in time")
+
+// The closest point of approach between two moving objects
+type Approach struct {
+	elapsed  time.Duration
+	distance *dist.Distance
+	first    *ll.LatLong
+	second   *ll.LatLong
+}
+
+// The time until the objects are closest (TCPA)
+func (this *Approach) Time() time.Duration {
+	return this.elapsed
+}
+
+// The distance between the objects when they are closest, i.e. the miss distance
+func (this *Approach) Distance() *dist.Distance {
+	return this.distance
+}
+
+// The position of the first object when they are closest
+func (this *Approach) First() *ll.LatLong {
+	return this.first
+}
+
+// The position of the second object when they are closest
+func (this *Approach) Second() *ll.LatLong {
+	return this.second
+}
+
+// Computes the closest point of approach of two objects at the provided positions, each following the Great Circle leaving it on
+// its course at its speed, within the horizon (the look ahead time). Objects already moving apart are closest now, and those
+// still converging at the horizon are closest there.
+func Between(first *ll.LatLong, firstCourse *crs.Course, firstSpeed *spd.Speed,
+	second *ll.LatLong, secondCourse *crs.Course, secondSpeed *spd.Speed, horizon time.Duration) *Approach {
+
+	positions := func(seconds float64) (*ll.LatLong, *ll.LatLong) {
+		elapsed := toDuration(seconds)
+		return first.DeadReckon(firstCourse, firstSpeed, elapsed), second.DeadReckon(secondCourse, secondSpeed, elapsed)
+	}
+
+	seconds := closest(positions, 0., math.Max(0., horizon.Seconds()), samples)
+	a, b := positions(seconds)
+	return &Approach{toDuration(seconds), a.DistanceTo(b), a, b}
+}
+
+// Computes the closest point of approach of the objects following the two tracks during the time they overlap, returning the time
+// it occurs with the TCPA measured from the start of the overlap, or an error matching ErrNoOverlap if there is none.
+func BetweenTracks(first, second *track.Track) (time.Time, *Approach, error) {
+
+	start, end := first.Start(), first.End()
+	if second.Start().After(start) {
+		start = second.Start()
+	}
+	if second.End().Before(end) {
+		end = second.End()
+	}
+	if end.Before(start) {
+		return time.Time{}, nil, fmt.Errorf("%w: %s to %s and %s to %s", ErrNoOverlap, first.Start(), first.End(),
+			second.Start(), second.End())
+	}
+
+	positions := func(seconds float64) (*ll.LatLong, *ll.LatLong) {
+		at := start.Add(toDuration(seconds))
+		if at.After(end) {
+			at = end
+		}
+		a, _ := first.PositionAt(at)
+		b, _ := second.PositionAt(at)
+		return a.LatLong(), b.LatLong()
+	}
+
+	// Both objects move at constant speeds along Great Circles between consecutive points of either track, so each interval is
+	// searched separately
+	breaks := []float64{0.}
+	for _, t := range []*track.Track{first, second} {
+		for _, p := range t.Points() {
+			if p.Time().After(start) && p.Time().Before(end) {
+				breaks = append(breaks, p.Time().Sub(start).Seconds())
+			}
+		}
+	}
+	breaks = append(breaks, end.Sub(start).Seconds())
+	sort.Float64s(breaks)
+
+	best, bestNm := 0., math.Inf(1)
+	for i := 1; i < len(breaks); i++ {
+		seconds := closest(positions, breaks[i-1], breaks[i], samples/4)
+		if a, b := positions(seconds); a.DistanceInNm(b) < bestNm {
+			best, bestNm = seconds, a.DistanceInNm(b)
+		}
+	}
+
+	a, b := positions(best)
+	return start.Add(toDuration(best)), &Approach{toDuration(best), a.DistanceTo(b), a, b}, nil
+}
+
+// Returns the time (in seconds) within [lo, hi] at which the positions are closest, checking evenly spaced times and then refining
+// around the closest of them with a golden section search.
+func closest(positions func(seconds float64) (*ll.LatLong, *ll.LatLong), lo, hi float64, n int) float64 {
+
+	separation := func(seconds float64) float64 {
+		a, b := positions(seconds)
+		return a.DistanceInNm(b)
+	}
+
+	step := (hi - lo) / float64(n)
+	if step <= 0. {
+		return lo
+	}
+
+	best, bestNm := lo, separation(lo)
+	for i := 1; i <= n; i++ {
+		if nm := separation(lo + float64(i)*step); nm < bestNm {
+			best, bestNm = lo+float64(i)*step, nm
+		}
+	}
+
+	a, b := math.Max(lo, best-step), math.Min(hi, best+step)
+	ratio := (math.Sqrt(5.) - 1.) / 2.
+	c, d := b-ratio*(b-a), a+ratio*(b-a)
+	fc, fd := separation(c), separation(d)
+	for b-a > timeTolerance {
+		if fc < fd {
+			b, d, fd = d, c, fc
+			c = b - ratio*(b-a)
+			fc = separation(c)
+		} else {
+			a, c, fc = c, d, fd
+			d = a + ratio*(b-a)
+			fd = separation(d)
+		}
+	}
+
+	// The ends of the interval aren't visited by the search but may be the closest, e.g. when objects are moving apart
+	refined := (a + b) / 2.
+	for _, candidate := range []float64{lo, hi} {
+		if separation(candidate) < separation(refined) {
+			refined = candidate
+		}
+	}
+	return refined
+}
+
+func toDuration(seconds float64) time.Duration {
+	return time.Duration(math.Round(seconds * float64(time.Second)))
+}
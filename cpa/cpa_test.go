@@ -0,0 +1,108 @@
+package cpa_test
+
+import (
+	"errors"
+	"math"
+	crs "stellarsunset/spherical/course"
+	"stellarsunset/spherical/cpa"
+	ll "stellarsunset/spherical/latlong"
+	spd "stellarsunset/spherical/speed"
+	"stellarsunset/spherical/track"
+	"testing"
+	"time"
+)
+
+func isTrue(t *testing.T, condition bool, s string) {
+	if !condition {
+		t.Error(s)
+	}
+}
+
+func isEqual(t *testing.T, expected, actual any) {
+	if expected != actual {
+		t.Errorf("want = %+v, got = %+v", expected, actual)
+	}
+}
+
+func withinError(t *testing.T, expected, actual, tolerance float64) {
+	if math.Abs(expected-actual) > tolerance {
+		t.Errorf("want = %f, got = %f, tol = %f", expected, actual, tolerance)
+	}
+}
+
+func TestHeadOn(t *testing.T) {
+
+	a, b := ll.NewLatLong(0., 0.), ll.NewLatLong(0., 1.)
+	approach := cpa.Between(a, crs.OfDegrees(90.), spd.OfKnots(120.), b, crs.OfDegrees(270.), spd.OfKnots(120.), time.Hour)
+
+	// Closing at 240 knots over a degree of longitude
+	withinError(t, a.DistanceInNm(b)/240.*3600., approach.Time().Seconds(), 1e-2)
+	withinError(t, 0., approach.Distance().InNauticalMiles(), 1e-4)
+	withinError(t, .5, approach.First().Longitude(), 1e-6)
+	withinError(t, .5, approach.Second().Longitude(), 1e-6)
+}
+
+func TestCrossing(t *testing.T) {
+
+	// Both reach the origin after travelling a degree
+	a, b := ll.NewLatLong(0., -1.), ll.NewLatLong(-1., 0.)
+	approach := cpa.Between(a, crs.OfDegrees(90.), spd.OfKnots(300.), b, crs.OfDegrees(0.), spd.OfKnots(300.), 30*time.Minute)
+
+	withinError(t, a.DistanceInNm(ll.NewLatLong(0., 0.))/300.*3600., approach.Time().Seconds(), 1e-2)
+	withinError(t, 0., approach.Distance().InNauticalMiles(), 1e-4)
+
+	// Offsetting the second object's start passes it behind the first, at the flat Earth miss distance of a 90 degree crossing at
+	// equal speeds, i.e. the offset divided by root two
+	b = ll.NewLatLong(-1.-5./60., 0.)
+	approach = cpa.Between(a, crs.OfDegrees(90.), spd.OfKnots(300.), b, crs.OfDegrees(0.), spd.OfKnots(300.), 30*time.Minute)
+	withinError(t, 5.*60.00687/60./math.Sqrt2, approach.Distance().InNauticalMiles(), 1e-2)
+	withinError(t, approach.First().DistanceInNm(approach.Second()), approach.Distance().InNauticalMiles(), 1e-12)
+}
+
+func TestDiverging(t *testing.T) {
+
+	a, b := ll.NewLatLong(10., 10.), ll.NewLatLong(10., 11.)
+	approach := cpa.Between(a, crs.OfDegrees(270.), spd.OfKnots(200.), b, crs.OfDegrees(90.), spd.OfKnots(200.), time.Hour)
+
+	isEqual(t, time.Duration(0), approach.Time())
+	withinError(t, a.DistanceInNm(b), approach.Distance().InNauticalMiles(), 1e-12)
+}
+
+func TestConvergingMeridians(t *testing.T) {
+
+	// Objects flying north in parallel on a flat Earth converge on the sphere as the meridians do, so are closest at the horizon
+	a, b := ll.NewLatLong(60., 0.), ll.NewLatLong(60., 1.)
+	approach := cpa.Between(a, crs.OfDegrees(0.), spd.OfKnots(400.), b, crs.OfDegrees(0.), spd.OfKnots(400.), 2*time.Hour)
+
+	withinError(t, 2*3600., approach.Time().Seconds(), 1e-2)
+	isTrue(t, approach.Distance().InNauticalMiles() < .6*a.DistanceInNm(b), "Converging")
+}
+
+func TestBetweenTracks(t *testing.T) {
+
+	epoch := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	a, b := ll.NewLatLong(0., -1.), ll.NewLatLong(-1.-5./60., 0.)
+
+	points := func(start *ll.LatLong, course float64, at time.Time, elapsed time.Duration) []*track.Point {
+		var points []*track.Point
+		for _, p := range start.DeadReckonEvery(at, crs.OfDegrees(course), spd.OfKnots(300.), 0., 37*time.Second, elapsed) {
+			points = append(points, track.NewPoint(p.Time(), p.LatLong()))
+		}
+		return points
+	}
+
+	// The second track starts later but from further back, so the kinematics match the crossing above
+	first := track.NewTrack(points(a, 90., epoch, 30*time.Minute)...)
+	second := track.NewTrack(points(b.ProjectOut(180., 5.), 0., epoch.Add(-time.Minute), 31*time.Minute)...)
+
+	at, approach, err := cpa.BetweenTracks(first, second)
+	isTrue(t, err == nil, "Error")
+
+	expected := cpa.Between(a, crs.OfDegrees(90.), spd.OfKnots(300.), b, crs.OfDegrees(0.), spd.OfKnots(300.), 30*time.Minute)
+	withinError(t, expected.Time().Seconds(), at.Sub(epoch).Seconds(), 1e-1)
+	withinError(t, expected.Time().Seconds(), approach.Time().Seconds(), 1e-1)
+	withinError(t, expected.Distance().InNauticalMiles(), approach.Distance().InNauticalMiles(), 1e-4)
+
+	_, _, err = cpa.BetweenTracks(first, track.NewTrack(points(b, 0., epoch.Add(time.Hour), time.Minute)...))
+	isTrue(t, errors.Is(err, cpa.ErrNoOverlap), "Overlap")
+}
@@ -0,0 +1,51 @@
+package ellipsoid
+
+import (
+	"math"
+	dist "stellarsunset/spherical/distance"
+	ll "stellarsunset/spherical/latlong"
+	vec "stellarsunset/spherical/vector"
+)
+
+// Number of iterations of Bowring's method used when converting from ECEF, sufficient for sub-millimeter accuracy for any point
+// from the center of the Earth out to geostationary orbit.
+const bowringIter int = 3
+
+// Returns the Earth-Centered Earth-Fixed position (in meters) of the provided LatLong at the provided height above this ellipsoid.
+func (this *Ellipsoid) ToECEF(position *ll.LatLong, altitude *dist.Distance) *vec.Vec3 {
+
+	g, h := this.geodesic, altitude.InMeters()
+
+	sinLat, cosLat := sincosd(position.Latitude())
+	sinLon, cosLon := sincosd(position.Longitude())
+
+	// Radius of curvature in the prime vertical
+	n := g.a / math.Sqrt(1.-g.e2*sq(sinLat))
+
+	return vec.Of((n+h)*cosLat*cosLon, (n+h)*cosLat*sinLon, (n*(1.-g.e2)+h)*sinLat)
+}
+
+// Returns the LatLong and height above this ellipsoid of an Earth-Centered Earth-Fixed position in meters.
+func (this *Ellipsoid) FromECEF(ecef *vec.Vec3) (*ll.LatLong, *dist.Distance) {
+	lat, lon, h := this.fromECEF(ecef.X(), ecef.Y(), ecef.Z())
+	return ll.Normalized(lat, lon), dist.OfMeters(h)
+}
+
+func (this *Ellipsoid) fromECEF(x, y, z float64) (latitude, longitude, height float64) {
+
+	g := this.geodesic
+	p := math.Hypot(x, y)
+	longitude = math.Atan2(y, x) * radiansToDegrees
+
+	// Bowring's method iterating on the parametric latitude
+	sinBeta, cosBeta := norm(g.f1*z, p)
+	var sinLat, cosLat float64
+	for i := 0; i < bowringIter; i++ {
+		sinLat, cosLat = norm(z+g.ep2*g.b*sinBeta*sinBeta*sinBeta, p-g.e2*g.a*cosBeta*cosBeta*cosBeta)
+		sinBeta, cosBeta = norm(g.f1*sinLat, cosLat)
+	}
+
+	// Height measured along the normal, well conditioned at all latitudes
+	height = p*cosLat + z*sinLat - g.a*math.Sqrt(1.-g.e2*sq(sinLat))
+	return atan2d(sinLat, cosLat), longitude, height
+}
@@ -0,0 +1,56 @@
+package ellipsoid_test
+
+import (
+	dist "stellarsunset/spherical/distance"
+	ell "stellarsunset/spherical/ellipsoid"
+	ll "stellarsunset/spherical/latlong"
+	vec "stellarsunset/spherical/vector"
+	"testing"
+)
+
+func TestToECEF(t *testing.T) {
+
+	wgs84 := ell.WGS84()
+
+	origin := wgs84.ToECEF(ll.NewLatLong(0., 0.), dist.Zero())
+	withinError(t, 6378137., origin.X(), 1e-6, "X")
+	withinError(t, 0., origin.Y(), 1e-6, "Y")
+	withinError(t, 0., origin.Z(), 1e-6, "Z")
+
+	east := wgs84.ToECEF(ll.NewLatLong(0., 90.), dist.OfMeters(100.))
+	withinError(t, 0., east.X(), 1e-6, "X")
+	withinError(t, 6378237., east.Y(), 1e-6, "Y")
+	withinError(t, 0., east.Z(), 1e-6, "Z")
+
+	pole := wgs84.ToECEF(ll.Normalized(-90., 0.), dist.Zero())
+	withinError(t, 0., pole.X(), 1e-6, "X")
+	withinError(t, -wgs84.PolarRadius().InMeters(), pole.Z(), 1e-6, "Z")
+}
+
+func TestFromECEFPole(t *testing.T) {
+
+	position, altitude := ell.WGS84().FromECEF(vec.Of(0., 0., 6356752.314245+100.))
+
+	withinError(t, 90., position.Latitude(), 1e-12, "Latitude")
+	withinError(t, 100., altitude.InMeters(), 1e-6, "Altitude")
+}
+
+func TestECEFRoundTrip(t *testing.T) {
+
+	wgs84 := ell.WGS84()
+
+	points := [][]float64{
+		{40.7128, -74.0060, 10.},
+		{-33.8688, 151.2093, -50.},
+		{89.9, 45., 35786000.},
+		{-0.1, 179.9, 12000.},
+	}
+
+	for _, p := range points {
+		position, altitude := wgs84.FromECEF(wgs84.ToECEF(ll.NewLatLong(p[0], p[1]), dist.OfMeters(p[2])))
+
+		withinError(t, p[0], position.Latitude(), 1e-10, "Latitude")
+		withinError(t, p[1], position.Longitude(), 1e-10, "Longitude")
+		withinError(t, p[2], altitude.InMeters(), 1e-4, "Altitude")
+	}
+}
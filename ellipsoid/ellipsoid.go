@@ -0,0 +1,93 @@
+/*
+This Ellipsoid package provides geodesic calculations on an ellipsoid of revolution (e.g. WGS-84) as a higher accuracy
+alternative to the spherical functions in the root package.
+
+Modelling the Earth as a sphere introduces errors of up to ~0.5% in distances, which is fine for many applications but not
+when results need to be reconciled against surveyed data. The inverse and direct solutions provided here use Karney's
+algorithms which are accurate to round-off and converge for all pairs of points, including nearly antipodal ones.
+*/
+package ellipsoid
+
+import (
+	"math"
+	crs "stellarsunset/spherical/course"
+	dist "stellarsunset/spherical/distance"
+	ll "stellarsunset/spherical/latlong"
+)
+
+const (
+	degreesToRadians float64 = math.Pi / 180.
+	radiansToDegrees float64 = 180. / math.Pi
+)
+
+type Ellipsoid struct {
+	equatorialRadius float64
+	flattening       float64
+	geodesic         *geodesic
+}
+
+var wgs84 = NewEllipsoid(dist.OfMeters(6378137.), 1./298.257223563)
+
+// The WGS-84 ellipsoid used by GPS and most modern aeronautical and nautical data sources
+func WGS84() *Ellipsoid {
+	return wgs84
+}
+
+// Creates a new Ellipsoid from its equatorial radius (semi-major axis) and flattening, a flattening of zero yields a sphere.
+func NewEllipsoid(equatorialRadius *dist.Distance, flattening float64) *Ellipsoid {
+	a := equatorialRadius.InMeters()
+	return &Ellipsoid{a, flattening, newGeodesic(a, flattening)}
+}
+
+func (this *Ellipsoid) EquatorialRadius() *dist.Distance {
+	return dist.OfMeters(this.equatorialRadius)
+}
+
+func (this *Ellipsoid) PolarRadius() *dist.Distance {
+	return dist.OfMeters(this.geodesic.b)
+}
+
+func (this *Ellipsoid) Flattening() float64 {
+	return this.flattening
+}
+
+// Solves the inverse geodesic problem, returning the length of the shortest path between the two LatLongs along with the
+// initial course at the start and the final course on arrival at the end, both in the range [0, 360).
+func (this *Ellipsoid) Inverse(from, to *ll.LatLong) (distance *dist.Distance, initial, final *crs.Course) {
+	meters, azi1, azi2 := this.InverseInMeters(from.Latitude(), from.Longitude(), to.Latitude(), to.Longitude())
+	return dist.OfMeters(meters), crs.OfDegrees(azi1), crs.OfDegrees(azi2)
+}
+
+// Solves the inverse geodesic problem for two (latitude, longitude) coordinates in degrees, returning the distance between
+// them in meters and the initial and final courses in degrees.
+func (this *Ellipsoid) InverseInMeters(lat1, lon1, lat2, lon2 float64) (meters, initialDegrees, finalDegrees float64) {
+	s := this.geodesic.inverse(lat1, lon1, lat2, lon2)
+	return s.s12, toCourse(atan2d(s.salp1, s.calp1)), toCourse(atan2d(s.salp2, s.calp2))
+}
+
+// Solves the direct geodesic problem, returning the LatLong reached by travelling the given distance along the geodesic
+// leaving the start on the provided course, along with the course on arrival there.
+//
+// Negative distances travel backwards along the geodesic.
+func (this *Ellipsoid) Direct(from *ll.LatLong, course *crs.Course, distance *dist.Distance) (*ll.LatLong, *crs.Course) {
+	lat, lon, azi := this.DirectInMeters(from.Latitude(), from.Longitude(), course.InDegrees(), distance.InMeters())
+	return ll.Normalized(lat, lon), crs.OfDegrees(azi)
+}
+
+// Solves the direct geodesic problem for a (latitude, longitude) coordinate and course in degrees and a distance in meters,
+// returning the destination and the final course in degrees.
+func (this *Ellipsoid) DirectInMeters(lat, lon, courseDegrees, meters float64) (latitude, longitude, finalDegrees float64) {
+	s := this.geodesic.direct(lat, lon, courseDegrees, meters)
+	return s.lat2, s.lon2, toCourse(s.azi2)
+}
+
+// Convert an azimuth in [-180, 180] to a course in [0, 360)
+func toCourse(azimuth float64) float64 {
+	if azimuth < 0. {
+		azimuth += 360.
+	}
+	if azimuth >= 360. {
+		return 0.
+	}
+	return azimuth + 0.
+}
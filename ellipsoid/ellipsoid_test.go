@@ -0,0 +1,164 @@
+package ellipsoid_test
+
+import (
+	"math"
+	crs "stellarsunset/spherical/course"
+	dist "stellarsunset/spherical/distance"
+	ell "stellarsunset/spherical/ellipsoid"
+	ll "stellarsunset/spherical/latlong"
+	"testing"
+)
+
+func withinError(t *testing.T, expected, actual, maxError float64, s string) {
+	if math.Abs(expected-actual) > maxError {
+		t.Errorf("%s: want = %f, got = %f, tol = %f", s, expected, actual, maxError)
+	}
+}
+
+func TestWGS84(t *testing.T) {
+
+	wgs84 := ell.WGS84()
+
+	withinError(t, 6378137., wgs84.EquatorialRadius().InMeters(), 1e-9, "EquatorialRadius()")
+	withinError(t, 6356752.314245, wgs84.PolarRadius().InMeters(), 1e-6, "PolarRadius()")
+	withinError(t, 1./298.257223563, wgs84.Flattening(), 1e-15, "Flattening()")
+}
+
+func TestInverse(t *testing.T) {
+
+	// Wellington, NZ to Salamanca, Spain - from Karney (2013)
+	wellington, salamanca := ll.NewLatLong(-41.32, 174.81), ll.NewLatLong(40.96, -5.50)
+
+	distance, initial, final := ell.WGS84().Inverse(wellington, salamanca)
+
+	withinError(t, 19959679.267, distance.InMeters(), .001, "Distance")
+	withinError(t, 161.067669986, initial.InDegrees(), 1e-8, "Initial")
+	withinError(t, 18.825195123, final.InDegrees(), 1e-8, "Final")
+}
+
+func TestInverseNearlyAntipodal(t *testing.T) {
+
+	// Newton's method alone fails to converge for these points
+	meters, initial, final := ell.WGS84().InverseInMeters(0., 0., .5, 179.5)
+
+	withinError(t, 19936288.579, meters, .001, "Distance")
+	withinError(t, 25.671872868, initial, 1e-8, "Initial")
+	withinError(t, 154.327085470, final, 1e-8, "Final")
+}
+
+func TestInverseAntipodalOnEquator(t *testing.T) {
+
+	// The shortest path between antipodal points on the equator passes over the poles
+	meters, initial, final := ell.WGS84().InverseInMeters(0., 0., 0., 180.)
+
+	withinError(t, 20003931.4586, meters, .001, "Distance")
+	withinError(t, 0., initial, 1e-8, "Initial")
+	withinError(t, 180., final, 1e-8, "Final")
+}
+
+func TestInverseSamePoint(t *testing.T) {
+
+	meters, _, _ := ell.WGS84().InverseInMeters(12., 34., 12., 34.)
+	withinError(t, 0., meters, 1e-9, "Distance")
+}
+
+func TestInverseAlongMeridian(t *testing.T) {
+
+	// Length of a degree of latitude at the equator
+	meters, initial, final := ell.WGS84().InverseInMeters(0., 10., 1., 10.)
+
+	withinError(t, 110574.389, meters, .001, "Distance")
+	withinError(t, 0., initial, 1e-9, "Initial")
+	withinError(t, 0., final, 1e-9, "Final")
+}
+
+func TestInverseCoursesInRange(t *testing.T) {
+
+	_, initial, final := ell.WGS84().InverseInMeters(10., 10., 0., 0.)
+
+	isInRange := func(c float64) bool { return 0. <= c && c < 360. }
+	if !isInRange(initial) || !isInRange(final) {
+		t.Errorf("Courses should be in [0, 360): initial = %f, final = %f", initial, final)
+	}
+}
+
+func TestInverseDiffersFromSphere(t *testing.T) {
+
+	one, two := ll.NewLatLong(0., 0.), ll.NewLatLong(10., 10.)
+
+	sphere := one.DistanceTo(two)
+	ellipsoid, _, _ := ell.WGS84().Inverse(one, two)
+
+	withinError(t, 1565109.099, ellipsoid.InMeters(), .001, "Distance")
+	if diff := math.Abs(sphere.InMeters() - ellipsoid.InMeters()); diff < 100. {
+		t.Errorf("Expected sphere and ellipsoid to differ by more than 100m, got %f", diff)
+	}
+}
+
+func TestDirect(t *testing.T) {
+
+	wellington := ll.NewLatLong(-41.32, 174.81)
+
+	salamanca, final := ell.WGS84().Direct(wellington, crs.OfDegrees(161.067669986), dist.OfMeters(19959679.267))
+
+	withinError(t, 40.96, salamanca.Latitude(), 1e-8, "Latitude")
+	withinError(t, -5.50, salamanca.Longitude(), 1e-8, "Longitude")
+	withinError(t, 18.825195123, final.InDegrees(), 1e-8, "Final")
+}
+
+func TestDirectOntoAntimeridianAndPole(t *testing.T) {
+
+	wgs84 := ell.WGS84()
+
+	// One degree of the equator east of 179E lands on the antimeridian, which is wrapped to -180
+	meters, _, _ := wgs84.InverseInMeters(0., 179., 0., 180.)
+	antimeridian, _ := wgs84.Direct(ll.NewLatLong(0., 179.), crs.OfDegrees(90.), dist.OfMeters(meters))
+	withinError(t, 0., antimeridian.Latitude(), 1e-8, "Antimeridian latitude")
+	withinError(t, 0., math.Remainder(antimeridian.Longitude()-180., 360.), 1e-8, "Antimeridian longitude")
+
+	// A quarter meridian due north from the equator lands on the North Pole
+	meters, _, _ = wgs84.InverseInMeters(0., 0., 90., 0.)
+	pole, _ := wgs84.Direct(ll.NewLatLong(0., 0.), crs.OfDegrees(0.), dist.OfMeters(meters))
+	withinError(t, 90., pole.Latitude(), 1e-8, "Pole latitude")
+}
+
+func TestDirectNegativeDistance(t *testing.T) {
+
+	lat, lon, _ := ell.WGS84().DirectInMeters(0., 0., 45.188040229, -156899.568)
+
+	withinError(t, -1., lat, 1e-6, "Latitude")
+	withinError(t, -1., lon, 1e-6, "Longitude")
+}
+
+func TestDirectInverseRoundTrip(t *testing.T) {
+
+	wgs84 := ell.WGS84()
+
+	points := [][]float64{
+		{40.7128, -74.0060, 35.6764, 139.6500},
+		{-33.8688, 151.2093, 51.5074, -0.1278},
+		{89.5, 0., -89.5, 179.},
+		{1., 179.9, -1., -179.9},
+	}
+
+	for _, p := range points {
+		meters, initial, final := wgs84.InverseInMeters(p[0], p[1], p[2], p[3])
+		lat, lon, azi := wgs84.DirectInMeters(p[0], p[1], initial, meters)
+
+		withinError(t, p[2], lat, 1e-9, "Latitude")
+		withinError(t, p[3], lon, 1e-9, "Longitude")
+		withinError(t, final, azi, 1e-9, "Final")
+	}
+}
+
+func TestSphericalEllipsoid(t *testing.T) {
+
+	// With zero flattening the results should match the spherical implementation
+	sphere := ell.NewEllipsoid(dist.OfMeters(6371008.8), 0.)
+
+	meters, initial, _ := sphere.InverseInMeters(0., 0., 10., 10.)
+
+	expected := 6371008.8 * math.Acos(math.Cos(10.*math.Pi/180.)*math.Cos(10.*math.Pi/180.))
+	withinError(t, expected, meters, 1e-6, "Distance")
+	withinError(t, 44.561451413, initial, 1e-8, "Initial")
+}
@@ -0,0 +1,73 @@
+package ellipsoid
+
+import (
+	dist "stellarsunset/spherical/distance"
+	ll "stellarsunset/spherical/latlong"
+	vec "stellarsunset/spherical/vector"
+)
+
+// A LocalFrame is a local tangent plane to an ellipsoid at an origin, giving flat Cartesian coordinates in meters for short
+// range geometry, e.g. a sensor's field of view or the environment around a runway.
+//
+// Positions are converted through ECEF so are exact at any range (not an equirectangular approximation), but the further they are
+// from the origin the further below the plane the surface of the Earth falls.
+type LocalFrame struct {
+	ellipsoid *Ellipsoid
+	origin    *ll.LatLong
+	altitude  *dist.Distance
+	// The ECEF position of the origin and the unit vectors of the east, north and up axes there
+	center, east, north, up *vec.Vec3
+}
+
+// Creates a LocalFrame with its origin on the surface of this ellipsoid at the provided LatLong
+func (this *Ellipsoid) LocalFrame(origin *ll.LatLong) *LocalFrame {
+	return this.LocalFrameAt(origin, dist.Zero())
+}
+
+// Creates a LocalFrame with its origin at the provided height above this ellipsoid at the LatLong
+func (this *Ellipsoid) LocalFrameAt(origin *ll.LatLong, altitude *dist.Distance) *LocalFrame {
+
+	sinLat, cosLat := sincosd(origin.Latitude())
+	sinLon, cosLon := sincosd(origin.Longitude())
+
+	return &LocalFrame{
+		ellipsoid: this,
+		origin:    origin,
+		altitude:  altitude,
+		center:    this.ToECEF(origin, altitude),
+		east:      vec.Of(-sinLon, cosLon, 0.),
+		north:     vec.Of(-sinLat*cosLon, -sinLat*sinLon, cosLat),
+		up:        vec.Of(cosLat*cosLon, cosLat*sinLon, sinLat),
+	}
+}
+
+func (this *LocalFrame) Origin() *ll.LatLong {
+	return this.origin
+}
+
+func (this *LocalFrame) Altitude() *dist.Distance {
+	return this.altitude
+}
+
+// Returns the east, north and up coordinates (in meters) of the LatLong at the provided height above the ellipsoid
+func (this *LocalFrame) ToENU(position *ll.LatLong, altitude *dist.Distance) *vec.Vec3 {
+	d := this.ellipsoid.ToECEF(position, altitude).Minus(this.center)
+	return vec.Of(d.Dot(this.east), d.Dot(this.north), d.Dot(this.up))
+}
+
+// Returns the LatLong and height above the ellipsoid of the east, north and up coordinates (in meters)
+func (this *LocalFrame) FromENU(enu *vec.Vec3) (*ll.LatLong, *dist.Distance) {
+	ecef := this.center.Plus(this.east.Times(enu.X())).Plus(this.north.Times(enu.Y())).Plus(this.up.Times(enu.Z()))
+	return this.ellipsoid.FromECEF(ecef)
+}
+
+// Returns the north, east and down coordinates (in meters) of the LatLong at the provided height above the ellipsoid
+func (this *LocalFrame) ToNED(position *ll.LatLong, altitude *dist.Distance) *vec.Vec3 {
+	enu := this.ToENU(position, altitude)
+	return vec.Of(enu.Y(), enu.X(), -enu.Z())
+}
+
+// Returns the LatLong and height above the ellipsoid of the north, east and down coordinates (in meters)
+func (this *LocalFrame) FromNED(ned *vec.Vec3) (*ll.LatLong, *dist.Distance) {
+	return this.FromENU(vec.Of(ned.Y(), ned.X(), -ned.Z()))
+}
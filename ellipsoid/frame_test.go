@@ -0,0 +1,72 @@
+package ellipsoid_test
+
+import (
+	crs "stellarsunset/spherical/course"
+	dist "stellarsunset/spherical/distance"
+	ell "stellarsunset/spherical/ellipsoid"
+	ll "stellarsunset/spherical/latlong"
+	vec "stellarsunset/spherical/vector"
+	"testing"
+)
+
+func TestLocalFrameOrigin(t *testing.T) {
+
+	frame := ell.WGS84().LocalFrameAt(ll.NewLatLong(51.4700, -0.4543), dist.OfMeters(25.))
+
+	enu := frame.ToENU(ll.NewLatLong(51.4700, -0.4543), dist.OfMeters(25.))
+	withinError(t, 0., enu.Norm(), 1e-6, "Origin")
+
+	above := frame.ToNED(ll.NewLatLong(51.4700, -0.4543), dist.OfMeters(125.))
+	withinError(t, 0., above.X(), 1e-6, "North")
+	withinError(t, 0., above.Y(), 1e-6, "East")
+	withinError(t, -100., above.Z(), 1e-6, "Down")
+}
+
+func TestLocalFrameAxes(t *testing.T) {
+
+	wgs84 := ell.WGS84()
+	origin := ll.NewLatLong(-33.9461, 151.1772)
+	frame := wgs84.LocalFrame(origin)
+
+	// A kilometer east along the geodesic is almost entirely along the x axis, dropping slightly below the plane
+	east := frame.ToENU(wgs84.Project(origin, crs.OfDegrees(90.), dist.OfMeters(1000.)), dist.Zero())
+	withinError(t, 1000., east.X(), 1e-3, "East")
+	withinError(t, 0., east.Y(), 1e-2, "North")
+	withinError(t, -0.0785, east.Z(), 1e-3, "Up")
+
+	north := frame.ToNED(wgs84.Project(origin, crs.OfDegrees(0.), dist.OfMeters(1000.)), dist.Zero())
+	withinError(t, 1000., north.X(), 1e-3, "North")
+	withinError(t, 0., north.Y(), 1e-6, "East")
+	withinError(t, 0.0785, north.Z(), 1e-3, "Down")
+}
+
+func TestLocalFrameRoundTrip(t *testing.T) {
+
+	origin := ll.NewLatLong(64.1283, -21.9406)
+	frame := ell.WGS84().LocalFrameAt(origin, dist.OfMeters(50.))
+
+	for c := 0.; c < 360.; c += 15. {
+		for _, nm := range []float64{0.1, 10., 50., 100.} {
+			position := origin.ProjectOut(c, nm)
+
+			returned, altitude := frame.FromENU(frame.ToENU(position, dist.OfMeters(300.)))
+			withinError(t, 0., position.DistanceInNm(returned), 1e-6, "ENU")
+			withinError(t, 300., altitude.InMeters(), 1e-3, "Altitude")
+
+			returned, altitude = frame.FromNED(frame.ToNED(position, dist.Zero()))
+			withinError(t, 0., position.DistanceInNm(returned), 1e-6, "NED")
+			withinError(t, 0., altitude.InMeters(), 1e-3, "Altitude")
+		}
+	}
+}
+
+func TestLocalFrameFromENU(t *testing.T) {
+
+	frame := ell.WGS84().LocalFrame(ll.NewLatLong(0., 179.9999))
+
+	// Frames straddling the antimeridian wrap longitudes as usual
+	position, altitude := frame.FromENU(vec.Of(100., 0., 0.))
+	withinError(t, 179.9999+100./111319.49-360., position.Longitude(), 1e-6, "Longitude")
+	withinError(t, 0., position.Latitude(), 1e-9, "Latitude")
+	withinError(t, 0., altitude.InMeters(), 1e-2, "Altitude")
+}
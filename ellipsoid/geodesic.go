@@ -0,0 +1,820 @@
+package ellipsoid
+
+import (
+	"math"
+)
+
+// The implementation in this file is a port of the geodesic routines of C. F. F. Karney's GeographicLib, described in
+// "Algorithms for geodesics", J. Geodesy 87, 43-55 (2013). The series expansions are carried out to sixth order in the third
+// flattening which makes the results accurate to round-off for terrestrial ellipsoids.
+
+const (
+	order    int = 6
+	nA3      int = order
+	nC3      int = order
+	maxIter1 int = 20
+	maxIter2 int = maxIter1 + 53 + 10
+)
+
+var (
+	// Square root of the smallest normal float64
+	tiny    = math.Sqrt(2.2250738585072014e-308)
+	tol0    = math.Nextafter(1., 2.) - 1.
+	tol1    = 200. * tol0
+	tol2    = math.Sqrt(tol0)
+	tolb    = tol0 * tol2
+	xthresh = 1000. * tol2
+)
+
+// Derived constants of an ellipsoid used by the inverse and direct solutions.
+type geodesic struct {
+	a   float64
+	f   float64
+	f1  float64
+	e2  float64
+	ep2 float64
+	n   float64
+	b   float64
+
+	etol2 float64
+	a3x   [nA3]float64
+	c3x   [(nC3 * (nC3 - 1)) / 2]float64
+}
+
+func newGeodesic(a, f float64) *geodesic {
+	g := &geodesic{a: a, f: f}
+	g.f1 = 1. - f
+	g.e2 = f * (2. - f)
+	g.ep2 = g.e2 / sq(g.f1)
+	g.n = f / (2. - f)
+	g.b = a * g.f1
+	g.etol2 = .1 * tol2 / math.Sqrt(math.Max(.001, math.Abs(f))*math.Min(1., 1.-f/2.)/2.)
+	g.a3coeff()
+	g.c3coeff()
+	return g
+}
+
+// The result of solving the inverse problem, angles are left as sine/cosine pairs.
+type inverseSolution struct {
+	s12          float64
+	salp1, calp1 float64
+	salp2, calp2 float64
+}
+
+// The result of solving the direct problem, all angles are in degrees.
+type directSolution struct {
+	lat2, lon2, azi2 float64
+}
+
+func (g *geodesic) inverse(lat1, lon1, lat2, lon2 float64) inverseSolution {
+
+	lon12, lon12s := angDiff(lon1, lon2)
+	lonsign := math.Copysign(1., lon12)
+	lon12 = lonsign * angRound(lon12)
+	lon12s = angRound((180. - lon12) - lonsign*lon12s)
+	lam12 := lon12 * degreesToRadians
+
+	var slam12, clam12 float64
+	if lon12 > 90. {
+		slam12, clam12 = sincosd(lon12s)
+		clam12 = -clam12
+	} else {
+		slam12, clam12 = sincosd(lon12)
+	}
+
+	lat1, lat2 = angRound(latFix(lat1)), angRound(latFix(lat2))
+
+	// Swap points so that the point with the larger absolute latitude is first
+	swapp := 1.
+	if math.Abs(lat1) < math.Abs(lat2) || math.IsNaN(lat2) {
+		swapp = -1.
+		lonsign *= -1.
+		lat1, lat2 = lat2, lat1
+	}
+	// Make lat1 <= -0
+	latsign := math.Copysign(1., -lat1)
+	lat1 *= latsign
+	lat2 *= latsign
+
+	sbet1, cbet1 := sincosd(lat1)
+	sbet1, cbet1 = norm(g.f1*sbet1, cbet1)
+	cbet1 = math.Max(tiny, cbet1)
+
+	sbet2, cbet2 := sincosd(lat2)
+	sbet2, cbet2 = norm(g.f1*sbet2, cbet2)
+	cbet2 = math.Max(tiny, cbet2)
+
+	if cbet1 < -sbet1 {
+		if cbet2 == cbet1 {
+			sbet2 = math.Copysign(sbet1, sbet2)
+		}
+	} else if math.Abs(sbet2) == -sbet1 {
+		cbet2 = cbet1
+	}
+
+	dn1 := math.Sqrt(1. + g.ep2*sq(sbet1))
+	dn2 := math.Sqrt(1. + g.ep2*sq(sbet2))
+
+	var c1a, c2a [order + 1]float64
+	var c3a [nC3]float64
+
+	var s12x, sig12, salp1, calp1, salp2, calp2 float64
+
+	meridian := lat1 == -90. || slam12 == 0.
+	if meridian {
+		// Endpoints are on a single full meridian so the geodesic might lie along it
+		calp1, salp1 = clam12, slam12
+		calp2, salp2 = 1., 0.
+
+		ssig1, csig1 := sbet1, calp1*cbet1
+		ssig2, csig2 := sbet2, calp2*cbet2
+
+		sig12 = math.Atan2(math.Max(0., csig1*ssig2-ssig1*csig2), csig1*csig2+ssig1*ssig2)
+
+		var m12x float64
+		s12x, m12x = g.lengths(g.n, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2, &c1a, &c2a)
+
+		if sig12 < 1. || m12x >= 0. {
+			if sig12 < 3.*tiny || (sig12 < tol0 && (s12x < 0. || m12x < 0.)) {
+				sig12, s12x = 0., 0.
+			}
+			s12x *= g.b
+		} else {
+			// m12 < 0, i.e. prolate and too close to anti-podal
+			meridian = false
+		}
+	}
+
+	if !meridian && sbet1 == 0. && (g.f <= 0. || lon12s >= g.f*180.) {
+		// Geodesic runs along the equator
+		calp1, calp2 = 0., 0.
+		salp1, salp2 = 1., 1.
+		s12x = g.a * lam12
+	} else if !meridian {
+
+		var dnm float64
+		sig12, salp1, calp1, salp2, calp2, dnm = g.inverseStart(sbet1, cbet1, dn1, sbet2, cbet2, dn2, lam12, slam12, clam12)
+
+		if sig12 >= 0. {
+			// Short lines, inverseStart has already solved the problem
+			s12x = sig12 * g.b * dnm
+		} else {
+			// Newton's method on the azimuth at the first point, falling back to bisection when Newton misbehaves
+			var ssig1, csig1, ssig2, csig2, eps float64
+
+			numit := 0
+			tripn, tripb := false, false
+			salp1a, calp1a := tiny, 1.
+			salp1b, calp1b := tiny, -1.
+
+			for numit < maxIter2 {
+				var v, dv float64
+				v, salp2, calp2, sig12, ssig1, csig1, ssig2, csig2, eps, dv = g.lambda12(
+					sbet1, cbet1, dn1, sbet2, cbet2, dn2, salp1, calp1, slam12, clam12, numit < maxIter1, &c1a, &c2a, &c3a)
+
+				limit := tol0
+				if tripn {
+					limit = 8. * tol0
+				}
+				if tripb || !(math.Abs(v) >= limit) {
+					break
+				}
+
+				// Update the bracketing values
+				if v > 0. && (numit > maxIter1 || calp1/salp1 > calp1b/salp1b) {
+					salp1b, calp1b = salp1, calp1
+				} else if v < 0. && (numit > maxIter1 || calp1/salp1 < calp1a/salp1a) {
+					salp1a, calp1a = salp1, calp1
+				}
+
+				numit++
+				if numit < maxIter1 && dv > 0. {
+					dalp1 := -v / dv
+					if math.Abs(dalp1) < math.Pi {
+						sdalp1, cdalp1 := math.Sincos(dalp1)
+						nsalp1 := salp1*cdalp1 + calp1*sdalp1
+						if nsalp1 > 0. {
+							calp1 = calp1*cdalp1 - salp1*sdalp1
+							salp1, calp1 = norm(nsalp1, calp1)
+							tripn = math.Abs(v) <= 16.*tol0
+							continue
+						}
+					}
+				}
+
+				salp1, calp1 = norm((salp1a+salp1b)/2., (calp1a+calp1b)/2.)
+				tripn = false
+				tripb = math.Abs(salp1a-salp1)+(calp1a-calp1) < tolb || math.Abs(salp1-salp1b)+(calp1-calp1b) < tolb
+			}
+
+			s12x, _ = g.lengths(eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2, &c1a, &c2a)
+			s12x *= g.b
+		}
+	}
+
+	if swapp < 0. {
+		salp1, salp2 = salp2, salp1
+		calp1, calp2 = calp2, calp1
+	}
+
+	salp1 *= swapp * lonsign
+	calp1 *= swapp * latsign
+	salp2 *= swapp * lonsign
+	calp2 *= swapp * latsign
+
+	return inverseSolution{s12: 0. + s12x, salp1: salp1, calp1: calp1, salp2: salp2, calp2: calp2}
+}
+
+func (g *geodesic) direct(lat1, lon1, azi1, s12 float64) directSolution {
+
+	lat1 = latFix(lat1)
+	salp1, calp1 := sincosd(angRound(azi1))
+
+	sbet1, cbet1 := sincosd(angRound(lat1))
+	sbet1, cbet1 = norm(g.f1*sbet1, cbet1)
+	cbet1 = math.Max(tiny, cbet1)
+
+	// Quantities describing the geodesic as a whole
+	salp0 := salp1 * cbet1
+	calp0 := math.Hypot(calp1, salp1*sbet1)
+
+	ssig1, somg1 := sbet1, salp0*sbet1
+	csig1 := 1.
+	if sbet1 != 0. || calp1 != 0. {
+		csig1 = cbet1 * calp1
+	}
+	comg1 := csig1
+	ssig1, csig1 = norm(ssig1, csig1)
+
+	k2 := sq(calp0) * g.ep2
+	eps := k2 / (2.*(1.+math.Sqrt(1.+k2)) + k2)
+
+	var c1a, c1pa [order + 1]float64
+	var c3a [nC3]float64
+
+	a1m1 := a1m1f(eps)
+	c1f(eps, &c1a)
+	b11 := sinCosSeries(true, ssig1, csig1, c1a[:])
+	sb11, cb11 := math.Sincos(b11)
+	stau1 := ssig1*cb11 + csig1*sb11
+	ctau1 := csig1*cb11 - ssig1*sb11
+
+	c1pf(eps, &c1pa)
+
+	g.c3f(eps, &c3a)
+	a3c := -g.f * salp0 * g.a3f(eps)
+	b31 := sinCosSeries(true, ssig1, csig1, c3a[:])
+
+	// Convert the distance to an arc length on the auxiliary sphere
+	tau12 := s12 / (g.b * (1. + a1m1))
+	stau12, ctau12 := math.Sincos(tau12)
+	b12 := -sinCosSeries(true, stau1*ctau12+ctau1*stau12, ctau1*ctau12-stau1*stau12, c1pa[:])
+	sig12 := tau12 - (b12 - b11)
+	ssig12, csig12 := math.Sincos(sig12)
+
+	if math.Abs(g.f) > .01 {
+		// The reverted series is only accurate for small flattening so take a single Newton step to clean it up
+		ssig2 := ssig1*csig12 + csig1*ssig12
+		csig2 := csig1*csig12 - ssig1*ssig12
+		b12 = sinCosSeries(true, ssig2, csig2, c1a[:])
+		serr := (1.+a1m1)*(sig12+(b12-b11)) - s12/g.b
+		sig12 = sig12 - serr/math.Sqrt(1.+k2*sq(ssig2))
+		ssig12, csig12 = math.Sincos(sig12)
+	}
+
+	ssig2 := ssig1*csig12 + csig1*ssig12
+	csig2 := csig1*csig12 - ssig1*ssig12
+
+	sbet2 := calp0 * ssig2
+	cbet2 := math.Hypot(salp0, calp0*csig2)
+	if cbet2 == 0. {
+		// Geodesic passes through a pole
+		cbet2, csig2 = tiny, tiny
+	}
+
+	salp2, calp2 := salp0, calp0*csig2
+
+	somg2, comg2 := salp0*ssig2, csig2
+	omg12 := math.Atan2(somg2*comg1-comg2*somg1, comg2*comg1+somg2*somg1)
+	lam12 := omg12 + a3c*(sig12+(sinCosSeries(true, ssig2, csig2, c3a[:])-b31))
+	lon12 := lam12 * radiansToDegrees
+
+	return directSolution{
+		lat2: atan2d(sbet2, g.f1*cbet2),
+		lon2: angNormalize(angNormalize(lon1) + angNormalize(lon12)),
+		azi2: atan2d(salp2, calp2),
+	}
+}
+
+// Computes the (scaled) distance and reduced length along a geodesic segment on the auxiliary sphere.
+func (g *geodesic) lengths(eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2 float64, c1a, c2a *[order + 1]float64) (s12b, m12b float64) {
+
+	a1 := a1m1f(eps)
+	c1f(eps, c1a)
+	a2 := a2m1f(eps)
+	c2f(eps, c2a)
+
+	m0x := a1 - a2
+	a1, a2 = 1.+a1, 1.+a2
+
+	b1 := sinCosSeries(true, ssig2, csig2, c1a[:]) - sinCosSeries(true, ssig1, csig1, c1a[:])
+	b2 := sinCosSeries(true, ssig2, csig2, c2a[:]) - sinCosSeries(true, ssig1, csig1, c2a[:])
+
+	s12b = a1 * (sig12 + b1)
+
+	j12 := m0x*sig12 + (a1*b1 - a2*b2)
+	m12b = dn2*(csig1*ssig2) - dn1*(ssig1*csig2) - csig1*csig2*j12
+	return s12b, m12b
+}
+
+// Computes a first approximation of the azimuth at the first point. If the points are close enough that the problem can be
+// solved directly this returns a non-negative arc length sig12 along with the final azimuth.
+func (g *geodesic) inverseStart(sbet1, cbet1, dn1, sbet2, cbet2, dn2, lam12, slam12, clam12 float64) (sig12, salp1, calp1, salp2, calp2, dnm float64) {
+
+	sig12 = -1.
+	salp2, calp2, dnm = math.NaN(), math.NaN(), math.NaN()
+
+	sbet12 := sbet2*cbet1 - cbet2*sbet1
+	cbet12 := cbet2*cbet1 + sbet2*sbet1
+	sbet12a := sbet2*cbet1 + cbet2*sbet1
+
+	shortline := cbet12 >= 0. && sbet12 < .5 && cbet2*lam12 < .5
+
+	var somg12, comg12 float64
+	if shortline {
+		sbetm2 := sq(sbet1 + sbet2)
+		sbetm2 /= sbetm2 + sq(cbet1+cbet2)
+		dnm = math.Sqrt(1. + g.ep2*sbetm2)
+		omg12 := lam12 / (g.f1 * dnm)
+		somg12, comg12 = math.Sincos(omg12)
+	} else {
+		somg12, comg12 = slam12, clam12
+	}
+
+	salp1 = cbet2 * somg12
+	if comg12 >= 0. {
+		calp1 = sbet12 + cbet2*sbet1*sq(somg12)/(1.+comg12)
+	} else {
+		calp1 = sbet12a - cbet2*sbet1*sq(somg12)/(1.-comg12)
+	}
+
+	ssig12 := math.Hypot(salp1, calp1)
+	csig12 := sbet1*sbet2 + cbet1*cbet2*comg12
+
+	if shortline && ssig12 < g.etol2 {
+		// Really short lines
+		salp2 = cbet1 * somg12
+		if comg12 >= 0. {
+			calp2 = sbet12 - cbet1*sbet2*(sq(somg12)/(1.+comg12))
+		} else {
+			calp2 = sbet12 - cbet1*sbet2*(1.-comg12)
+		}
+		salp2, calp2 = norm(salp2, calp2)
+		sig12 = math.Atan2(ssig12, csig12)
+	} else if math.Abs(g.n) > .1 || csig12 >= 0. || ssig12 >= 6.*math.Abs(g.n)*math.Pi*sq(cbet1) {
+		// Nothing to do, the zeroth order spherical approximation is fine
+	} else {
+		// Nearly antipodal points, scale to the astroid problem
+		lam12x := math.Atan2(-slam12, -clam12)
+
+		var x, y, lamscale, betscale float64
+		if g.f >= 0. {
+			k2 := sq(sbet1) * g.ep2
+			eps := k2 / (2.*(1.+math.Sqrt(1.+k2)) + k2)
+			lamscale = g.f * cbet1 * g.a3f(eps) * math.Pi
+			betscale = lamscale * cbet1
+			x = lam12x / lamscale
+			y = sbet12a / betscale
+		} else {
+			cbet12a := cbet2*cbet1 - sbet2*sbet1
+			bet12a := math.Atan2(sbet12a, cbet12a)
+			var c1a, c2a [order + 1]float64
+			_, m12b := g.lengths(g.n, math.Pi+bet12a, sbet1, -cbet1, dn1, sbet2, cbet2, dn2, &c1a, &c2a)
+			m0 := a1m1f(g.n) - a2m1f(g.n)
+			x = -1. + m12b/(cbet1*cbet2*m0*math.Pi)
+			if x < -.01 {
+				betscale = sbet12a / x
+			} else {
+				betscale = -g.f * sq(cbet1) * math.Pi
+			}
+			lamscale = betscale / cbet1
+			y = lam12x / lamscale
+		}
+
+		if y > -tol1 && x > -1.-xthresh {
+			if g.f >= 0. {
+				salp1 = math.Min(1., -x)
+				calp1 = -math.Sqrt(1. - sq(salp1))
+			} else {
+				if x > -tol1 {
+					calp1 = math.Max(0., x)
+				} else {
+					calp1 = math.Max(-1., x)
+				}
+				salp1 = math.Sqrt(1. - sq(calp1))
+			}
+		} else {
+			k := astroid(x, y)
+			var omg12a float64
+			if g.f >= 0. {
+				omg12a = lamscale * (-x * k / (1. + k))
+			} else {
+				omg12a = lamscale * (-y * (1. + k) / k)
+			}
+			somg12, comg12 = math.Sincos(omg12a)
+			comg12 = -comg12
+			salp1 = cbet2 * somg12
+			calp1 = sbet12a - cbet2*sbet1*sq(somg12)/(1.-comg12)
+		}
+	}
+
+	if !(salp1 <= 0.) {
+		salp1, calp1 = norm(salp1, calp1)
+	} else {
+		salp1, calp1 = 1., 0.
+	}
+	return sig12, salp1, calp1, salp2, calp2, dnm
+}
+
+// Computes the longitude difference between the two points for a trial azimuth at the first point (minus the target value), along
+// with its derivative with respect to that azimuth when requested.
+func (g *geodesic) lambda12(sbet1, cbet1, dn1, sbet2, cbet2, dn2, salp1, calp1, slam120, clam120 float64, diffp bool,
+	c1a, c2a *[order + 1]float64, c3a *[nC3]float64) (lam12, salp2, calp2, sig12, ssig1, csig1, ssig2, csig2, eps, dlam12 float64) {
+
+	if sbet1 == 0. && calp1 == 0. {
+		// Break degeneracy of equatorial line
+		calp1 = -tiny
+	}
+
+	salp0 := salp1 * cbet1
+	calp0 := math.Hypot(calp1, salp1*sbet1)
+
+	ssig1, somg1 := sbet1, salp0*sbet1
+	csig1 = calp1 * cbet1
+	comg1 := csig1
+	ssig1, csig1 = norm(ssig1, csig1)
+
+	if cbet2 != cbet1 {
+		salp2 = salp0 / cbet2
+	} else {
+		salp2 = salp1
+	}
+
+	if cbet2 != cbet1 || math.Abs(sbet2) != -sbet1 {
+		var t float64
+		if cbet1 < -sbet1 {
+			t = (cbet2 - cbet1) * (cbet1 + cbet2)
+		} else {
+			t = (sbet1 - sbet2) * (sbet1 + sbet2)
+		}
+		calp2 = math.Sqrt(sq(calp1*cbet1)+t) / cbet2
+	} else {
+		calp2 = math.Abs(calp1)
+	}
+
+	ssig2, somg2 := sbet2, salp0*sbet2
+	csig2 = calp2 * cbet2
+	comg2 := csig2
+	ssig2, csig2 = norm(ssig2, csig2)
+
+	sig12 = math.Atan2(math.Max(0., csig1*ssig2-ssig1*csig2), csig1*csig2+ssig1*ssig2)
+
+	somg12 := math.Max(0., comg1*somg2-somg1*comg2)
+	comg12 := comg1*comg2 + somg1*somg2
+	eta := math.Atan2(somg12*clam120-comg12*slam120, comg12*clam120+somg12*slam120)
+
+	k2 := sq(calp0) * g.ep2
+	eps = k2 / (2.*(1.+math.Sqrt(1.+k2)) + k2)
+
+	g.c3f(eps, c3a)
+	b312 := sinCosSeries(true, ssig2, csig2, c3a[:]) - sinCosSeries(true, ssig1, csig1, c3a[:])
+	lam12 = eta - g.f*g.a3f(eps)*salp0*(sig12+b312)
+
+	if diffp {
+		if calp2 == 0. {
+			dlam12 = -2. * g.f1 * dn1 / sbet1
+		} else {
+			_, dlam12 = g.lengths(eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2, c1a, c2a)
+			dlam12 *= g.f1 / (calp2 * cbet2)
+		}
+	} else {
+		dlam12 = math.NaN()
+	}
+
+	return lam12, salp2, calp2, sig12, ssig1, csig1, ssig2, csig2, eps, dlam12
+}
+
+func (g *geodesic) a3coeff() {
+	coeff := [...]float64{
+		-3, 128,
+		-2, -3, 64,
+		-1, -3, -1, 16,
+		3, -1, -2, 8,
+		1, -1, 2,
+		1, 1,
+	}
+	o, k := 0, 0
+	for j := nA3 - 1; j >= 0; j-- {
+		m := minInt(nA3-j-1, j)
+		g.a3x[k] = polyval(m, coeff[o:], g.n) / coeff[o+m+1]
+		k++
+		o += m + 2
+	}
+}
+
+func (g *geodesic) c3coeff() {
+	coeff := [...]float64{
+		3, 128,
+		2, 5, 128,
+		-1, 3, 3, 64,
+		-1, 0, 1, 8,
+		-1, 1, 4,
+		5, 256,
+		1, 3, 128,
+		-3, -2, 3, 64,
+		1, -3, 2, 32,
+		7, 512,
+		-10, 9, 384,
+		5, -9, 5, 192,
+		7, 512,
+		-14, 7, 512,
+		21, 2560,
+	}
+	o, k := 0, 0
+	for l := 1; l < nC3; l++ {
+		for j := nC3 - 1; j >= l; j-- {
+			m := minInt(nC3-j-1, j)
+			g.c3x[k] = polyval(m, coeff[o:], g.n) / coeff[o+m+1]
+			k++
+			o += m + 2
+		}
+	}
+}
+
+func (g *geodesic) a3f(eps float64) float64 {
+	return polyval(nA3-1, g.a3x[:], eps)
+}
+
+func (g *geodesic) c3f(eps float64, c *[nC3]float64) {
+	mult, o := 1., 0
+	for l := 1; l < nC3; l++ {
+		m := nC3 - l - 1
+		mult *= eps
+		c[l] = mult * polyval(m, g.c3x[o:], eps)
+		o += m + 1
+	}
+}
+
+func a1m1f(eps float64) float64 {
+	coeff := [...]float64{
+		1, 4, 64, 0, 256,
+	}
+	m := order / 2
+	t := polyval(m, coeff[:], sq(eps)) / coeff[m+1]
+	return (t + eps) / (1. - eps)
+}
+
+func a2m1f(eps float64) float64 {
+	coeff := [...]float64{
+		-11, -28, -192, 0, 256,
+	}
+	m := order / 2
+	t := polyval(m, coeff[:], sq(eps)) / coeff[m+1]
+	return (t - eps) / (1. + eps)
+}
+
+func c1f(eps float64, c *[order + 1]float64) {
+	coeff := [...]float64{
+		-1, 6, -16, 32,
+		-9, 64, -128, 2048,
+		9, -16, 768,
+		3, -5, 512,
+		-7, 1280,
+		-7, 2048,
+	}
+	seriesCoefficients(eps, coeff[:], c)
+}
+
+func c1pf(eps float64, c *[order + 1]float64) {
+	coeff := [...]float64{
+		205, -432, 768, 1536,
+		4005, -4736, 3840, 12288,
+		-225, 116, 384,
+		-7173, 2695, 7680,
+		3467, 7680,
+		38081, 61440,
+	}
+	seriesCoefficients(eps, coeff[:], c)
+}
+
+func c2f(eps float64, c *[order + 1]float64) {
+	coeff := [...]float64{
+		1, 2, 16, 32,
+		35, 64, 384, 2048,
+		15, 80, 768,
+		7, 35, 512,
+		63, 1280,
+		77, 2048,
+	}
+	seriesCoefficients(eps, coeff[:], c)
+}
+
+// Evaluates the packed polynomial coefficients shared by the C1, C1' and C2 series.
+func seriesCoefficients(eps float64, coeff []float64, c *[order + 1]float64) {
+	eps2, d, o := sq(eps), eps, 0
+	for l := 1; l <= order; l++ {
+		m := (order - l) / 2
+		c[l] = d * polyval(m, coeff[o:], eps2) / coeff[o+m+1]
+		o += m + 2
+		d *= eps
+	}
+}
+
+// Evaluates sum(c[i] * sin(2 * i * x), i, 1, n) (or the cosine equivalent) using Clenshaw summation.
+func sinCosSeries(sinp bool, sinx, cosx float64, c []float64) float64 {
+	k := len(c)
+	n := k
+	if sinp {
+		n--
+	}
+	ar := 2. * (cosx - sinx) * (cosx + sinx)
+	y0, y1 := 0., 0.
+	if n&1 != 0 {
+		k--
+		y0 = c[k]
+	}
+	for n /= 2; n > 0; n-- {
+		k--
+		y1 = ar*y0 - y1 + c[k]
+		k--
+		y0 = ar*y1 - y0 + c[k]
+	}
+	if sinp {
+		return 2. * sinx * cosx * y0
+	}
+	return cosx * (y0 - y1)
+}
+
+// Solves k^4 + 2 k^3 - (x^2 + y^2 - 1) k^2 - 2 y^2 k - y^2 = 0 for the positive root k.
+func astroid(x, y float64) float64 {
+	p, q := sq(x), sq(y)
+	r := (p + q - 1.) / 6.
+	if q == 0. && r <= 0. {
+		return 0.
+	}
+
+	s := p * q / 4.
+	r2 := sq(r)
+	r3 := r * r2
+	disc := s * (s + 2.*r3)
+	u := r
+	if disc >= 0. {
+		t3 := s + r3
+		if t3 < 0. {
+			t3 -= math.Sqrt(disc)
+		} else {
+			t3 += math.Sqrt(disc)
+		}
+		t := math.Cbrt(t3)
+		u += t
+		if t != 0. {
+			u += r2 / t
+		}
+	} else {
+		ang := math.Atan2(math.Sqrt(-disc), -(s + r3))
+		u += 2. * r * math.Cos(ang/3.)
+	}
+
+	v := math.Sqrt(sq(u) + q)
+	var uv float64
+	if u < 0. {
+		uv = q / (v - u)
+	} else {
+		uv = u + v
+	}
+	w := (uv - q) / (2. * v)
+	return uv / (math.Sqrt(uv+sq(w)) + w)
+}
+
+func polyval(n int, p []float64, x float64) float64 {
+	if n < 0 {
+		return 0.
+	}
+	y := p[0]
+	for i := 1; i <= n; i++ {
+		y = y*x + p[i]
+	}
+	return y
+}
+
+func sq(x float64) float64 {
+	return x * x
+}
+
+func minInt(x, y int) int {
+	if x < y {
+		return x
+	}
+	return y
+}
+
+func norm(x, y float64) (float64, float64) {
+	r := math.Hypot(x, y)
+	return x / r, y / r
+}
+
+// Round an angle so that small values underflow to zero, this reduces the chance of spurious sign changes near the equator.
+func angRound(x float64) float64 {
+	z := 1. / 16.
+	y := math.Abs(x)
+	if y < z {
+		y = z - (z - y)
+	}
+	return math.Copysign(y, x)
+}
+
+// Normalize an angle in degrees to [-180, 180]
+func angNormalize(x float64) float64 {
+	y := math.Remainder(x, 360.)
+	if math.Abs(y) == 180. {
+		return math.Copysign(180., x)
+	}
+	return y
+}
+
+func latFix(x float64) float64 {
+	if math.Abs(x) > 90. {
+		return math.NaN()
+	}
+	return x
+}
+
+// Exact difference y - x of two angles in degrees reduced to [-180, 180], along with the round-off error.
+func angDiff(x, y float64) (float64, float64) {
+	d, t := twoSum(math.Remainder(-x, 360.), math.Remainder(y, 360.))
+	d, e := twoSum(math.Remainder(d, 360.), t)
+	if d == 0. || math.Abs(d) == 180. {
+		if e == 0. {
+			d = math.Copysign(d, y-x)
+		} else {
+			d = math.Copysign(d, -e)
+		}
+	}
+	return d, e
+}
+
+func twoSum(u, v float64) (float64, float64) {
+	s := u + v
+	up := s - v
+	vpp := s - up
+	up -= u
+	vpp -= v
+	return s, -(up + vpp)
+}
+
+// Sine and cosine of an angle in degrees with exact results for multiples of 90 degrees.
+func sincosd(x float64) (float64, float64) {
+	r := math.Mod(x, 360.)
+	q := 0
+	if !math.IsNaN(r) {
+		q = int(math.Round(r / 90.))
+	}
+	r -= 90. * float64(q)
+	s, c := math.Sincos(r * degreesToRadians)
+	switch ((q % 4) + 4) % 4 {
+	case 1:
+		s, c = c, -s
+	case 2:
+		s, c = -s, -c
+	case 3:
+		s, c = -c, s
+	}
+	c += 0.
+	if s == 0. {
+		s = math.Copysign(s, x)
+	}
+	return s, c
+}
+
+// The arc tangent of y/x in degrees, reducing the arguments first so that the result is exact for multiples of 45 degrees.
+func atan2d(y, x float64) float64 {
+	q := 0
+	if math.Abs(y) > math.Abs(x) {
+		q = 2
+		x, y = y, x
+	}
+	if x < 0. {
+		q++
+		x = -x
+	}
+	ang := math.Atan2(y, x) * radiansToDegrees
+	switch q {
+	case 1:
+		ang = math.Copysign(180., y) - ang
+	case 2:
+		ang = 90. - ang
+	case 3:
+		ang = -90. + ang
+	}
+	return ang
+}
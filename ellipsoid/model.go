@@ -0,0 +1,81 @@
+package ellipsoid
+
+import (
+	"math"
+	crs "stellarsunset/spherical/course"
+	dist "stellarsunset/spherical/distance"
+	ll "stellarsunset/spherical/latlong"
+)
+
+const (
+	// Convergence threshold (in meters) for the iterative track distance computations
+	interceptTolerance float64 = 1e-9
+	maxInterceptIter   int     = 50
+)
+
+// Ellipsoid implements the EarthModel interface so it can be used anywhere a Sphere can
+var _ ll.EarthModel = (*Ellipsoid)(nil)
+
+func (this *Ellipsoid) Distance(from, to *ll.LatLong) *dist.Distance {
+	meters, _, _ := this.InverseInMeters(from.Latitude(), from.Longitude(), to.Latitude(), to.Longitude())
+	return dist.OfMeters(meters)
+}
+
+func (this *Ellipsoid) Course(from, to *ll.LatLong) *crs.Course {
+	_, initial, _ := this.InverseInMeters(from.Latitude(), from.Longitude(), to.Latitude(), to.Longitude())
+	return crs.OfDegrees(initial)
+}
+
+func (this *Ellipsoid) Project(from *ll.LatLong, course *crs.Course, distance *dist.Distance) *ll.LatLong {
+	destination, _ := this.Direct(from, course, distance)
+	return destination
+}
+
+// Computes the cross track distance between the geodesic defined by the provided start and end point and the provided position.
+//
+// This value will be negative if the position is to the left of the geodesic and positive if it is to the right.
+func (this *Ellipsoid) CrossTrack(start, end, position *ll.LatLong) *dist.Distance {
+	crossTrack, _ := this.intercept(start, end, position)
+	return dist.OfMeters(crossTrack)
+}
+
+// Computes the distance along the geodesic from start towards end to the point closest to the provided position.
+//
+// This value will be negative if the point is prior to the start point along the geodesic and positive if it occurs after.
+func (this *Ellipsoid) AlongTrack(start, end, position *ll.LatLong) *dist.Distance {
+	_, alongTrack := this.intercept(start, end, position)
+	return dist.OfMeters(alongTrack)
+}
+
+// Locates the foot of the perpendicular from the position onto the geodesic through start and end, returning the signed cross
+// and along track distances in meters.
+//
+// This follows Baselga and Martinez-Llario (2018), repeatedly moving a trial point along the geodesic by the along track
+// distance to the foot of the perpendicular computed on the sphere tangent to it until the correction vanishes.
+func (this *Ellipsoid) intercept(start, end, position *ll.LatLong) (crossTrack, alongTrack float64) {
+
+	_, course, _ := this.InverseInMeters(start.Latitude(), start.Longitude(), end.Latitude(), end.Longitude())
+
+	a := this.equatorialRadius
+	lat, lon, azi := start.Latitude(), start.Longitude(), course
+
+	for i := 0; i < maxInterceptIter; i++ {
+		meters, toPosition, _ := this.InverseInMeters(lat, lon, position.Latitude(), position.Longitude())
+		if meters == 0. {
+			return 0., alongTrack
+		}
+
+		angle := (toPosition - azi) * degreesToRadians
+		sd, cd := math.Sincos(meters / a)
+		step := a * math.Atan2(sd*math.Cos(angle), cd)
+
+		crossTrack = math.Copysign(meters, math.Sin(angle))
+		if math.Abs(step) < interceptTolerance {
+			break
+		}
+
+		alongTrack += step
+		lat, lon, azi = this.DirectInMeters(start.Latitude(), start.Longitude(), course, alongTrack)
+	}
+	return crossTrack, alongTrack
+}
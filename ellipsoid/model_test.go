@@ -0,0 +1,69 @@
+package ellipsoid_test
+
+import (
+	crs "stellarsunset/spherical/course"
+	dist "stellarsunset/spherical/distance"
+	ell "stellarsunset/spherical/ellipsoid"
+	ll "stellarsunset/spherical/latlong"
+	"testing"
+)
+
+func TestModelDistanceAndCourse(t *testing.T) {
+
+	one, two := ll.NewLatLong(0., 0.), ll.NewLatLong(10., 10.)
+
+	withinError(t, 1565109.099, one.DistanceUsing(ell.WGS84(), two).InMeters(), .001, "Distance")
+	withinError(t, 44.751910, one.CourseUsing(ell.WGS84(), two).InDegrees(), 1e-6, "Course")
+}
+
+func TestModelProject(t *testing.T) {
+
+	one := ll.NewLatLong(0., 0.)
+	two := one.ProjectUsing(ell.WGS84(), crs.OfDegrees(0.), dist.OfMeters(110574.389))
+
+	withinError(t, 1., two.Latitude(), 1e-8, "Latitude")
+	withinError(t, 0., two.Longitude(), 1e-8, "Longitude")
+}
+
+func TestModelCrossTrackAlongEquator(t *testing.T) {
+
+	// The equator is a geodesic and the meridian through the position is perpendicular to it
+	start, end := ll.NewLatLong(0., 0.), ll.NewLatLong(0., 10.)
+	left, right := ll.NewLatLong(1., 5.), ll.NewLatLong(-1., 5.)
+
+	meridian, _, _ := ell.WGS84().InverseInMeters(0., 5., 1., 5.)
+	equator, _, _ := ell.WGS84().InverseInMeters(0., 0., 0., 5.)
+
+	withinError(t, -meridian, left.CrossTrackDistanceUsing(ell.WGS84(), start, end).InMeters(), 1e-6, "Left")
+	withinError(t, meridian, right.CrossTrackDistanceUsing(ell.WGS84(), start, end).InMeters(), 1e-6, "Right")
+	withinError(t, equator, left.AlongTrackDistanceUsing(ell.WGS84(), start, end).InMeters(), 1e-6, "AlongTrack")
+}
+
+func TestModelAlongTrackBehindStart(t *testing.T) {
+
+	start, end, position := ll.NewLatLong(0., 0.), ll.NewLatLong(0., 10.), ll.NewLatLong(1., -.5)
+
+	equator, _, _ := ell.WGS84().InverseInMeters(0., 0., 0., .5)
+	withinError(t, -equator, position.AlongTrackDistanceUsing(ell.WGS84(), start, end).InMeters(), 1e-6, "AlongTrack")
+}
+
+func TestModelCrossTrackIsPerpendicular(t *testing.T) {
+
+	wgs84 := ell.WGS84()
+	start, end, position := ll.NewLatLong(40., -74.), ll.NewLatLong(51., 0.), ll.NewLatLong(50., -30.)
+
+	cross := position.CrossTrackDistanceUsing(wgs84, start, end)
+	along := position.AlongTrackDistanceUsing(wgs84, start, end)
+
+	foot := start.ProjectUsing(wgs84, start.CourseUsing(wgs84, end), along)
+	withinError(t, cross.Abs().InMeters(), foot.DistanceUsing(wgs84, position).InMeters(), 1e-6, "CrossTrack")
+
+	// The great circle from NYC to London arcs north of 50N over the Atlantic
+	if !cross.IsPositive() {
+		t.Errorf("Expected position to be right of track, got %f", cross.InMeters())
+	}
+
+	// The result should be close to the spherical one
+	sphere := position.CrossTrackDistanceTo(start, end)
+	withinError(t, sphere.InNauticalMiles(), cross.InNauticalMiles(), .01*sphere.Abs().InNauticalMiles(), "Sphere")
+}
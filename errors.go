@@ -0,0 +1,94 @@
+package spherical
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	// Returned when any of the numeric inputs to a function is NaN
+	ErrNaNInput = errors.New("input cannot be NaN")
+	// Returned when a track is too poorly conditioned to compute a distance along it
+	ErrDegenerateTrack = errors.New("track is degenerate")
+	// Returned when two paths lie on the same great circle and therefore have no unique intersection
+	ErrCoincidentGreatCircles = errors.New("great circles are coincident")
+	// Returned when a rhumb line that isn't due north or south would need to pass a pole, which it can only spiral into
+	ErrPastPole = errors.New("rhumb line cannot pass a pole")
+)
+
+func checkHeading(headingDegrees float64) error {
+	if math.IsNaN(headingDegrees) {
+		return fmt.Errorf("%w: heading", ErrNaNInput)
+	}
+	return nil
+}
+
+func checkDistance(distanceNm float64) error {
+	if math.IsNaN(distanceNm) {
+		return fmt.Errorf("%w: distance", ErrNaNInput)
+	}
+	return nil
+}
+
+func checkCoordinates(coordinates ...float64) error {
+	for _, coordinate := range coordinates {
+		if math.IsNaN(coordinate) {
+			return fmt.Errorf("%w: coordinates", ErrNaNInput)
+		}
+	}
+	return nil
+}
+
+// Compute a new (latitude, longitude) location by projecting along the Great Circle defined by the starting location and direction
+// the provided distance in NM, returning ErrNaNInput rather than panicking if any of the inputs are NaN.
+func ProjectOutE(lat, lon, headingDegrees, distanceNm float64) (latitude, longitude float64, err error) {
+	if err := errors.Join(checkCoordinates(lat, lon), checkHeading(headingDegrees), checkDistance(distanceNm)); err != nil {
+		return math.NaN(), math.NaN(), err
+	}
+	latitude, longitude = ProjectOut(lat, lon, headingDegrees, distanceNm)
+	return latitude, longitude, nil
+}
+
+// Compute a new (latitude, longitude) location by travelling the provided distance in NM from the starting location while holding
+// the provided course constant, returning ErrNaNInput rather than panicking if any of the inputs are NaN and ErrPastPole if the
+// path would need to pass a pole on a course other than due north or south.
+func RhumbProjectOutE(lat, lon, headingDegrees, distanceNm float64) (latitude, longitude float64, err error) {
+	if err := errors.Join(checkCoordinates(lat, lon), checkHeading(headingDegrees), checkDistance(distanceNm)); err != nil {
+		return math.NaN(), math.NaN(), err
+	}
+	latitude, longitude, pastPole := rhumbProjectOut(lat, lon, headingDegrees, distanceNm)
+	if pastPole {
+		return math.NaN(), math.NaN(), fmt.Errorf("%w: travelling %f nm on %f degrees from (%f, %f)", ErrPastPole, distanceNm,
+			headingDegrees, lat, lon)
+	}
+	return latitude, longitude, nil
+}
+
+// Computes the distance along the track (in nautical miles) from start point to end point and the provided position using the
+// provided cross track distance, returning ErrNaNInput if any of the inputs are NaN and ErrDegenerateTrack rather than panicking
+// if the inputs are inconsistent.
+func AlongTrackDistanceNmE(startLat, startLon, endLat, endLon, posLat, posLon, crossTrackDistanceNm float64) (float64, error) {
+	if err := errors.Join(checkCoordinates(startLat, startLon, endLat, endLon, posLat, posLon), checkDistance(crossTrackDistanceNm)); err != nil {
+		return math.NaN(), err
+	}
+	return alongTrackDistanceNmE(startLat, startLon, endLat, endLon, posLat, posLon, crossTrackDistanceNm)
+}
+
+// Compute the point on the Great Circle segment between the start and end location that is closest to the provided position,
+// returning ErrNaNInput if any of the inputs are NaN and ErrDegenerateTrack rather than panicking if the segment is too poorly
+// conditioned to measure along.
+func ClosestPointOnSegmentE(startLat, startLon, endLat, endLon, posLat, posLon float64) (latitude, longitude, distanceNm float64, location SegmentLocation, err error) {
+	if err := checkCoordinates(startLat, startLon, endLat, endLon, posLat, posLon); err != nil {
+		return math.NaN(), math.NaN(), math.NaN(), SegmentStart, err
+	}
+	return closestPointOnSegment(startLat, startLon, endLat, endLon, posLat, posLon)
+}
+
+// Compute the distance (in nautical miles) between the provided position and the closest point to it on the Great Circle segment
+// between the start and end location, returning ErrNaNInput if any of the inputs are NaN and ErrDegenerateTrack rather than
+// panicking if the segment's Great Circle is undefined.
+func DistanceToSegmentNmE(startLat, startLon, endLat, endLon, posLat, posLon float64) (float64, error) {
+	_, _, distanceNm, _, err := ClosestPointOnSegmentE(startLat, startLon, endLat, endLon, posLat, posLon)
+	return distanceNm, err
+}
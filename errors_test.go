@@ -0,0 +1,111 @@
+package spherical_test
+
+import (
+	"errors"
+	"math"
+	sph "stellarsunset/spherical"
+	"testing"
+)
+
+func isErr(t *testing.T, expected, actual error, s string) {
+	if !errors.Is(actual, expected) {
+		t.Errorf("%s: want = %v, got = %v", s, expected, actual)
+	}
+}
+
+func TestProjectOutE(t *testing.T) {
+
+	lat, lon, err := sph.ProjectOutE(0., 0., 45., 84.860371)
+
+	isErr(t, nil, err, "ProjectOutE(...)")
+	withinError(t, 1., lat, .01, "Latitude")
+	withinError(t, 1., lon, .01, "Longitude")
+}
+
+func TestProjectOutENaN(t *testing.T) {
+
+	_, _, err := sph.ProjectOutE(0., 0., math.NaN(), 1.)
+	isErr(t, sph.ErrNaNInput, err, "Heading")
+
+	_, _, err = sph.ProjectOutE(0., 0., 0., math.NaN())
+	isErr(t, sph.ErrNaNInput, err, "Distance")
+
+	_, _, err = sph.ProjectOutE(math.NaN(), 0., 0., 1.)
+	isErr(t, sph.ErrNaNInput, err, "Latitude")
+}
+
+func TestRhumbProjectOutENaN(t *testing.T) {
+
+	_, _, err := sph.RhumbProjectOutE(0., 0., math.NaN(), 1.)
+	isErr(t, sph.ErrNaNInput, err, "Heading")
+
+	_, _, err = sph.RhumbProjectOutE(0., math.NaN(), 0., 1.)
+	isErr(t, sph.ErrNaNInput, err, "Longitude")
+}
+
+func TestRhumbProjectOutEPastPole(t *testing.T) {
+
+	_, _, err := sph.RhumbProjectOutE(89., 0., 45., 200.)
+	isErr(t, sph.ErrPastPole, err, "Spiral")
+
+	lat, lon, err := sph.RhumbProjectOutE(89., 0., 0., sph.DistanceInNm(89., 0., 89., 180.))
+	isErr(t, nil, err, "Meridian")
+	withinError(t, 89., lat, 1e-9, "Latitude")
+	withinError(t, -180., lon, 1e-9, "Longitude")
+}
+
+func TestAlongTrackDistanceNmE(t *testing.T) {
+
+	cross := sph.CrossTrackDistanceNm(0., 0., 0., 10., 1., .5)
+	actual, err := sph.AlongTrackDistanceNmE(0., 0., 0., 10., 1., .5, cross)
+
+	isErr(t, nil, err, "AlongTrackDistanceNmE(...)")
+	withinError(t, 30.00343415285915, actual, .0001, "AlongTrackDistanceNmE(...)")
+}
+
+func TestAlongTrackDistanceNmEDegenerate(t *testing.T) {
+
+	// The provided cross track distance is inconsistent with the position
+	_, err := sph.AlongTrackDistanceNmE(0., 0., 0., 10., 1., .5, 3000.)
+	isErr(t, sph.ErrDegenerateTrack, err, "AlongTrackDistanceNmE(...)")
+
+	_, err = sph.AlongTrackDistanceNmE(0., 0., 0., 10., 1., .5, math.NaN())
+	isErr(t, sph.ErrNaNInput, err, "AlongTrackDistanceNmE(...)")
+}
+
+func TestAlongTrackDistanceNmPanics(t *testing.T) {
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("AlongTrackDistanceNm(...) should panic on an inconsistent cross track distance")
+		}
+	}()
+	sph.AlongTrackDistanceNm(0., 0., 0., 10., 1., .5, 3000.)
+}
+
+func TestClosestPointOnSegmentE(t *testing.T) {
+
+	_, _, nm, location, err := sph.ClosestPointOnSegmentE(0., 0., 0., 10., 1., 5.)
+
+	isErr(t, nil, err, "ClosestPointOnSegmentE(...)")
+	withinError(t, 60.00686673640662, nm, .0001, "Distance")
+	if location != sph.SegmentInterior {
+		t.Errorf("ClosestPointOnSegmentE(...) location = %s, want %s", location, sph.SegmentInterior)
+	}
+
+	_, _, _, _, err = sph.ClosestPointOnSegmentE(0., 0., 0., 10., math.NaN(), 5.)
+	isErr(t, sph.ErrNaNInput, err, "ClosestPointOnSegmentE(NaN)")
+}
+
+func TestDistanceToSegmentNmE(t *testing.T) {
+
+	nm, err := sph.DistanceToSegmentNmE(0., 0., 0., 10., 1., .5)
+	isErr(t, nil, err, "DistanceToSegmentNmE(...)")
+	withinError(t, sph.DistanceToSegmentNm(0., 0., 0., 10., 1., .5), nm, 1e-9, "DistanceToSegmentNmE(...)")
+
+	_, err = sph.DistanceToSegmentNmE(0., 0., 0., 180., 1., .5)
+	isErr(t, sph.ErrDegenerateTrack, err, "Antipodal endpoints")
+
+	_, err = sph.DistanceToSegmentNmE(0., 0., 0., 10., math.NaN(), .5)
+	isErr(t, sph.ErrNaNInput, err, "NaN position")
+}
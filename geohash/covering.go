@@ -0,0 +1,66 @@
+package geohash
+
+import (
+	"math"
+	sph "stellarsunset/spherical"
+	dist "stellarsunset/spherical/distance"
+	ll "stellarsunset/spherical/latlong"
+)
+
+// Returns the geohashes of all the cells of the provided precision containing any point within the given distance of the center,
+// so every point within the radius of the center shares a prefix with one of the returned geohashes.
+//
+// The number of cells returned grows with the square of the ratio of the radius to the cell size, so the precision should be
+// chosen such that cells are comparable in size to the radius.
+func Covering(center *ll.LatLong, radius *dist.Distance, precision int) []string {
+
+	start := Encode(center, precision)
+	radiusNm := radius.InNauticalMiles()
+
+	// The cells intersecting a circle are connected so can be found by flood filling outwards from the center
+	covering, visited, queue := []string{}, map[string]bool{start: true}, []string{start}
+	for len(queue) > 0 {
+		hash := queue[0]
+		queue = queue[1:]
+
+		if hash != start && distanceToCellNm(center, hash) > radiusNm {
+			continue
+		}
+		covering = append(covering, hash)
+
+		neighbors, _ := Neighbors(hash)
+		for _, n := range neighbors {
+			if !visited[n] {
+				visited[n] = true
+				queue = append(queue, n)
+			}
+		}
+	}
+	return covering
+}
+
+// Computes the distance (in nautical miles) from the position to the closest point in the cell identified by the geohash
+func distanceToCellNm(position *ll.LatLong, hash string) float64 {
+
+	south, north, west, east, _ := bounds(hash)
+	lat, lon := position.Latitude(), position.Longitude()
+
+	inLongitude := west <= lon && lon <= east
+	if inLongitude && south <= lat && lat <= north {
+		return 0.
+	}
+
+	// The western and eastern edges are meridians, so Great Circle segments
+	closest := math.Min(
+		sph.DistanceToSegmentNm(south, west, north, west, lat, lon),
+		sph.DistanceToSegmentNm(south, east, north, east, lat, lon),
+	)
+
+	// The southern and northern edges are parallels, the closest point on which lies due north or south if it falls between the
+	// edges' longitudes and otherwise is one of the corners (so already accounted for)
+	if inLongitude {
+		degrees := math.Min(math.Abs(lat-south), math.Abs(lat-north))
+		closest = math.Min(closest, degrees*math.Pi/180.*sph.EarthRadiusNm)
+	}
+	return closest
+}
@@ -0,0 +1,54 @@
+package geohash_test
+
+import (
+	dist "stellarsunset/spherical/distance"
+	"stellarsunset/spherical/geohash"
+	ll "stellarsunset/spherical/latlong"
+	"testing"
+)
+
+// Check every point at (or just inside) the radius falls in one of the covering cells
+func checkCovers(t *testing.T, center *ll.LatLong, radiusNm float64, precision int) []string {
+
+	covering := geohash.Covering(center, dist.OfNauticalMiles(radiusNm), precision)
+
+	cells := map[string]bool{}
+	for _, c := range covering {
+		cells[c] = true
+	}
+
+	for _, d := range []float64{0., radiusNm / 2., radiusNm - 1e-6} {
+		for course := 0.; course < 360.; course += 2. {
+			p := center.ProjectOut(course, d)
+			isTrue(t, cells[geohash.Encode(ll.Normalized(p.Latitude(), p.Longitude()), precision)], "Covered")
+		}
+	}
+	return covering
+}
+
+func TestCovering(t *testing.T) {
+
+	covering := checkCovers(t, ll.NewLatLong(40.7128, -74.0060), 5., 5)
+
+	// Precision 5 cells are ~2.6x2.4NM at this latitude, so a 10NM diameter circle needs no more than 7x7
+	isTrue(t, len(covering) <= 49, "Size")
+
+	// A tiny circle well within a cell is covered by it alone
+	isEqual(t, 1, len(geohash.Covering(ll.NewLatLong(42.605, -5.603), dist.OfFeet(1.), 5)))
+}
+
+func TestCoveringAntimeridian(t *testing.T) {
+	checkCovers(t, ll.NewLatLong(0., 179.99), 20., 4)
+}
+
+func TestCoveringPole(t *testing.T) {
+
+	covering := checkCovers(t, ll.NewLatLong(89.9, 0.), 30., 3)
+
+	// Every cell in the northernmost row at this precision touches the pole
+	cells := map[string]bool{}
+	for _, c := range covering {
+		cells[c] = true
+	}
+	isTrue(t, cells["zzz"] && cells["bpb"], "Polar row")
+}
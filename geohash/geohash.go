@@ -0,0 +1,137 @@
+/*
+This Geohash package encodes LatLongs as short base-32 strings identifying rectangular latitude/longitude cells, for use as
+locality-preserving keys in key-value stores.
+
+Each additional character subdivides the cell of its prefix into 32 children, so points sharing a long common prefix are close
+to one another and the set of points within a cell can be found with a prefix scan.
+*/
+package geohash
+
+import (
+	"errors"
+	"fmt"
+	ll "stellarsunset/spherical/latlong"
+	"strings"
+)
+
+// The longest supported geohash, cells at this precision are a few centimeters across
+const MaxPrecision int = 12
+
+var (
+	ErrInvalidPrecision = errors.New("Geohash precision is out of range [1, 12]")
+	ErrInvalidCharacter = errors.New("Geohash contains an invalid character")
+)
+
+const alphabet string = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// Encodes the LatLong as a geohash with the provided number of characters, panicking if EncodeE would return an error.
+func Encode(position *ll.LatLong, precision int) string {
+	hash, err := EncodeE(position, precision)
+	if err != nil {
+		panic(err)
+	}
+	return hash
+}
+
+// Encodes the LatLong as a geohash with the provided number of characters, or returns an error matching ErrInvalidPrecision if
+// the precision is outside [1, MaxPrecision].
+func EncodeE(position *ll.LatLong, precision int) (string, error) {
+	if precision < 1 || MaxPrecision < precision {
+		return "", fmt.Errorf("%w: %d", ErrInvalidPrecision, precision)
+	}
+
+	south, north, west, east := -90., 90., -180., 180.
+
+	hash := make([]byte, precision)
+	for i := range hash {
+		index := 0
+		for bit := 0; bit < 5; bit++ {
+			// Bits alternate between longitude and latitude, starting with longitude
+			index <<= 1
+			if (i*5+bit)%2 == 0 {
+				if mid := (west + east) / 2.; position.Longitude() >= mid {
+					index, west = index|1, mid
+				} else {
+					east = mid
+				}
+			} else {
+				if mid := (south + north) / 2.; position.Latitude() >= mid {
+					index, south = index|1, mid
+				} else {
+					north = mid
+				}
+			}
+		}
+		hash[i] = alphabet[index]
+	}
+	return string(hash), nil
+}
+
+// Decodes the geohash returning the center of the cell it identifies along with the cell itself, or an error matching either
+// ErrInvalidPrecision or ErrInvalidCharacter if the geohash is malformed. Geohashes are case-insensitive.
+func Decode(hash string) (*ll.LatLong, *ll.BoundingBox, error) {
+	south, north, west, east, err := bounds(hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ll.Normalized((south+north)/2., (west+east)/2.), ll.NewBoundingBox(south, north, west, east), nil
+}
+
+// Returns the geohash of the cell containing this one, one character shorter
+func Parent(hash string) (string, error) {
+	if _, _, _, _, err := bounds(hash); err != nil {
+		return "", err
+	}
+	if len(hash) == 1 {
+		return "", fmt.Errorf("%w: single character geohash %q has no parent", ErrInvalidPrecision, hash)
+	}
+	return strings.ToLower(hash[:len(hash)-1]), nil
+}
+
+// Returns the geohashes of the 32 cells subdividing this one, one character longer
+func Children(hash string) ([]string, error) {
+	if _, _, _, _, err := bounds(hash); err != nil {
+		return nil, err
+	}
+	if len(hash) == MaxPrecision {
+		return nil, fmt.Errorf("%w: geohash %q is already at maximum precision", ErrInvalidPrecision, hash)
+	}
+	children := make([]string, len(alphabet))
+	for i := range alphabet {
+		children[i] = strings.ToLower(hash) + alphabet[i:i+1]
+	}
+	return children, nil
+}
+
+// Computes the edges of the cell identified by the geohash in degrees
+func bounds(hash string) (south, north, west, east float64, err error) {
+	if len(hash) < 1 || MaxPrecision < len(hash) {
+		return 0., 0., 0., 0., fmt.Errorf("%w: %q has %d characters", ErrInvalidPrecision, hash, len(hash))
+	}
+
+	south, north, west, east = -90., 90., -180., 180.
+
+	for i, c := range strings.ToLower(hash) {
+		index := strings.IndexRune(alphabet, c)
+		if index < 0 {
+			return 0., 0., 0., 0., fmt.Errorf("%w: %q at position %d of %q", ErrInvalidCharacter, c, i, hash)
+		}
+		for bit := 4; bit >= 0; bit-- {
+			set := index>>bit&1 == 1
+			if (i*5+4-bit)%2 == 0 {
+				if mid := (west + east) / 2.; set {
+					west = mid
+				} else {
+					east = mid
+				}
+			} else {
+				if mid := (south + north) / 2.; set {
+					south = mid
+				} else {
+					north = mid
+				}
+			}
+		}
+	}
+	return south, north, west, east, nil
+}
@@ -0,0 +1,126 @@
+package geohash_test
+
+import (
+	"errors"
+	"math"
+	"stellarsunset/spherical/geohash"
+	ll "stellarsunset/spherical/latlong"
+	"testing"
+)
+
+func isTrue(t *testing.T, condition bool, s string) {
+	if !condition {
+		t.Error(s)
+	}
+}
+
+func isEqual(t *testing.T, expected, actual any) {
+	if expected != actual {
+		t.Errorf("want = %+v, got = %+v", expected, actual)
+	}
+}
+
+func withinError(t *testing.T, expected, actual, tolerance float64) {
+	if math.Abs(expected-actual) > tolerance {
+		t.Errorf("want = %f, got = %f, tol = %f", expected, actual, tolerance)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	isEqual(t, "u4pruydqqvj", geohash.Encode(ll.NewLatLong(57.64911, 10.40744), 11))
+	isEqual(t, "u4pru", geohash.Encode(ll.NewLatLong(57.64911, 10.40744), 5))
+	isEqual(t, "s0000", geohash.Encode(ll.NewLatLong(0., 0.), 5))
+	isEqual(t, "7zzzz", geohash.Encode(ll.NewLatLong(-1e-9, -1e-9), 5))
+}
+
+func TestEncodeInvalidPrecision(t *testing.T) {
+	defer func() {
+		err, _ := recover().(error)
+		isTrue(t, errors.Is(err, geohash.ErrInvalidPrecision), "Precision")
+	}()
+	geohash.Encode(ll.NewLatLong(0., 0.), 13)
+}
+
+func TestEncodeE(t *testing.T) {
+	hash, err := geohash.EncodeE(ll.NewLatLong(57.64911, 10.40744), 5)
+	isTrue(t, err == nil, "Error")
+	isEqual(t, "u4pru", hash)
+
+	_, err = geohash.EncodeE(ll.NewLatLong(0., 0.), 0)
+	isTrue(t, errors.Is(err, geohash.ErrInvalidPrecision), "Precision")
+}
+
+func TestDecode(t *testing.T) {
+
+	center, box, err := geohash.Decode("ezs42")
+	isTrue(t, err == nil, "Error")
+
+	withinError(t, 42.605, center.Latitude(), 1e-3)
+	withinError(t, -5.603, center.Longitude(), 1e-3)
+
+	withinError(t, 42.583, box.South(), 1e-3)
+	withinError(t, 42.627, box.North(), 1e-3)
+	withinError(t, -5.625, box.West(), 1e-3)
+	withinError(t, -5.581, box.East(), 1e-3)
+
+	// Upper case is accepted
+	upper, _, _ := geohash.Decode("EZS42")
+	isEqual(t, *center, *upper)
+}
+
+func TestDecodeInvalid(t *testing.T) {
+
+	_, _, err := geohash.Decode("")
+	isTrue(t, errors.Is(err, geohash.ErrInvalidPrecision), "Empty")
+
+	_, _, err = geohash.Decode("ezs42ezs42ezs")
+	isTrue(t, errors.Is(err, geohash.ErrInvalidPrecision), "Too long")
+
+	// 'a' isn't part of the geohash alphabet
+	_, _, err = geohash.Decode("ezsa2")
+	isTrue(t, errors.Is(err, geohash.ErrInvalidCharacter), "Invalid character")
+}
+
+func TestRoundTrip(t *testing.T) {
+	for lat := -89.5; lat < 90.; lat += 7.3 {
+		for lon := -179.5; lon < 180.; lon += 11.1 {
+			p := ll.NewLatLong(lat, lon)
+			for precision := 1; precision <= geohash.MaxPrecision; precision++ {
+				hash := geohash.Encode(p, precision)
+				center, box, _ := geohash.Decode(hash)
+				isTrue(t, box.Contains(p), "Contains")
+				isEqual(t, hash, geohash.Encode(center, precision))
+			}
+		}
+	}
+}
+
+func TestParent(t *testing.T) {
+
+	parent, err := geohash.Parent("ezs42")
+	isTrue(t, err == nil, "Error")
+	isEqual(t, "ezs4", parent)
+
+	_, err = geohash.Parent("e")
+	isTrue(t, errors.Is(err, geohash.ErrInvalidPrecision), "No parent")
+}
+
+func TestChildren(t *testing.T) {
+
+	_, parent, _ := geohash.Decode("ezs4")
+
+	children, err := geohash.Children("ezs4")
+	isTrue(t, err == nil, "Error")
+	isEqual(t, 32, len(children))
+
+	for _, child := range children {
+		p, _ := geohash.Parent(child)
+		isEqual(t, "ezs4", p)
+
+		center, _, _ := geohash.Decode(child)
+		isTrue(t, parent.Contains(center), "Child within parent")
+	}
+
+	_, err = geohash.Children("u4pruydqqvjz")
+	isTrue(t, errors.Is(err, geohash.ErrInvalidPrecision), "No children")
+}
@@ -0,0 +1,77 @@
+package geohash
+
+import (
+	"errors"
+	"fmt"
+	ll "stellarsunset/spherical/latlong"
+)
+
+// Returned when looking for the neighbor of a cell on the edge of the grid beyond one of the poles
+var ErrNoNeighbor = errors.New("Geohash has no neighbor beyond the pole")
+
+// The compass direction of a neighboring cell
+type Direction int
+
+const (
+	North Direction = iota
+	NorthEast
+	East
+	SouthEast
+	South
+	SouthWest
+	West
+	NorthWest
+)
+
+var directions = [...]struct {
+	name       string
+	rows, cols float64
+}{
+	North:     {"North", 1., 0.},
+	NorthEast: {"NorthEast", 1., 1.},
+	East:      {"East", 0., 1.},
+	SouthEast: {"SouthEast", -1., 1.},
+	South:     {"South", -1., 0.},
+	SouthWest: {"SouthWest", -1., -1.},
+	West:      {"West", 0., -1.},
+	NorthWest: {"NorthWest", 1., -1.},
+}
+
+func (this Direction) String() string {
+	return directions[this].name
+}
+
+// Returns the geohash of the adjacent cell of the same precision in the provided direction, wrapping across the antimeridian.
+//
+// Returns an error matching ErrNoNeighbor for cells north of the northernmost (or south of the southernmost) row.
+func Neighbor(hash string, direction Direction) (string, error) {
+	south, north, west, east, err := bounds(hash)
+	if err != nil {
+		return "", err
+	}
+
+	d := directions[direction]
+	lat := (south+north)/2. + d.rows*(north-south)
+	lon := (west+east)/2. + d.cols*(east-west)
+
+	if lat < -90. || 90. < lat {
+		return "", fmt.Errorf("%w: %s of %q", ErrNoNeighbor, direction, hash)
+	}
+	return Encode(ll.Normalized(lat, lon), len(hash)), nil
+}
+
+// Returns the geohashes of the (up to) eight cells of the same precision surrounding the provided one, in clockwise order
+// starting from the north, omitting those beyond the poles.
+func Neighbors(hash string) ([]string, error) {
+	if _, _, _, _, err := bounds(hash); err != nil {
+		return nil, err
+	}
+
+	neighbors := make([]string, 0, len(directions))
+	for d := range directions {
+		if neighbor, err := Neighbor(hash, Direction(d)); err == nil {
+			neighbors = append(neighbors, neighbor)
+		}
+	}
+	return neighbors, nil
+}
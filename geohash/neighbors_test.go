@@ -0,0 +1,65 @@
+package geohash_test
+
+import (
+	"errors"
+	"stellarsunset/spherical/geohash"
+	"testing"
+)
+
+func TestNeighbor(t *testing.T) {
+
+	_, box, _ := geohash.Decode("ezs42")
+
+	north, err := geohash.Neighbor("ezs42", geohash.North)
+	isTrue(t, err == nil, "Error")
+
+	_, nbox, _ := geohash.Decode(north)
+	withinError(t, box.North(), nbox.South(), 1e-12)
+	withinError(t, box.West(), nbox.West(), 1e-12)
+
+	southWest, _ := geohash.Neighbor("ezs42", geohash.SouthWest)
+	_, swbox, _ := geohash.Decode(southWest)
+	withinError(t, box.South(), swbox.North(), 1e-12)
+	withinError(t, box.West(), swbox.East(), 1e-12)
+
+	// Directions are symmetric
+	back, _ := geohash.Neighbor(southWest, geohash.NorthEast)
+	isEqual(t, "ezs42", back)
+}
+
+func TestNeighborAntimeridian(t *testing.T) {
+
+	// The easternmost cell on the equator
+	east, _ := geohash.Neighbor("rzzz", geohash.East)
+	_, box, _ := geohash.Decode(east)
+	isEqual(t, -180., box.West())
+}
+
+func TestNeighborPole(t *testing.T) {
+
+	// The north-easternmost cell
+	_, err := geohash.Neighbor("zzzz", geohash.North)
+	isTrue(t, errors.Is(err, geohash.ErrNoNeighbor), "Past pole")
+
+	neighbors, err := geohash.Neighbors("zzzz")
+	isTrue(t, err == nil, "Error")
+	isEqual(t, 5, len(neighbors))
+}
+
+func TestNeighbors(t *testing.T) {
+
+	neighbors, _ := geohash.Neighbors("ezs42")
+	isEqual(t, 8, len(neighbors))
+
+	for i, d := range []geohash.Direction{geohash.North, geohash.NorthEast, geohash.East, geohash.SouthEast, geohash.South, geohash.SouthWest, geohash.West, geohash.NorthWest} {
+		n, _ := geohash.Neighbor("ezs42", d)
+		isEqual(t, n, neighbors[i])
+	}
+
+	_, err := geohash.Neighbors("ezsa2")
+	isTrue(t, errors.Is(err, geohash.ErrInvalidCharacter), "Invalid")
+}
+
+func TestDirectionString(t *testing.T) {
+	isEqual(t, "NorthEast", geohash.NorthEast.String())
+}
@@ -0,0 +1,334 @@
+/*
+This Index package provides an in-memory spatial index of arbitrary payloads keyed by LatLong, answering nearest neighbor, radius
+and bounding box queries without scanning every entry.
+
+The index is a vantage-point tree over the Great Circle distance from DistanceInNm. As that is a true metric the triangle
+inequality lets whole subtrees be skipped while keeping results exact, i.e. identical to a linear scan using LatLong.IsWithin.
+
+The tree is built incrementally and never rebalanced. Deleting entries removes leaves as they empty, but otherwise leaves the
+splits made while inserting in place, so after heavy churn (or inserts in a pathological order) queries can slow down. Building a
+new index from the remaining entries restores its balance.
+*/
+package index
+
+import (
+	"math"
+	"sort"
+	sph "stellarsunset/spherical"
+	dist "stellarsunset/spherical/distance"
+	ll "stellarsunset/spherical/latlong"
+)
+
+const (
+	// Maximum number of entries held in a leaf before it is split around a vantage point
+	bucketSize int = 16
+	// Slack (in nautical miles) added to the pruning bounds so round-off in the distances can't exclude a matching entry
+	pruneToleranceNm float64 = 1e-9
+)
+
+// An Entry is a payload stored in the index at a given LatLong, returned by Insert so it can later be deleted
+type Entry[T any] struct {
+	position *ll.LatLong
+	payload  T
+}
+
+func (this *Entry[T]) Position() *ll.LatLong {
+	return this.position
+}
+
+func (this *Entry[T]) Payload() T {
+	return this.payload
+}
+
+type Index[T any] struct {
+	root *node[T]
+	size int
+}
+
+// Internal nodes split entries by whether they are within the radius of the vantage point, leaves hold the entries themselves
+type node[T any] struct {
+	vantage  *ll.LatLong
+	radiusNm float64
+	inside   *node[T]
+	outside  *node[T]
+	entries  []*Entry[T]
+	// Whether every entry of the leaf is at the same position, so it can't be split however many there are
+	duplicates bool
+}
+
+func (this *node[T]) isLeaf() bool {
+	return this.vantage == nil
+}
+
+func NewIndex[T any]() *Index[T] {
+	return &Index[T]{root: &node[T]{}}
+}
+
+// The number of entries in the index
+func (this *Index[T]) Size() int {
+	return this.size
+}
+
+// Adds the payload to the index at the provided LatLong, returning the Entry created for it
+func (this *Index[T]) Insert(position *ll.LatLong, payload T) *Entry[T] {
+	entry := &Entry[T]{position, payload}
+
+	path := this.pathTo(position)
+	n := path[len(path)-1]
+
+	// A leaf of duplicates can grow without retrying the split until an entry elsewhere is added
+	if n.duplicates && n.entries[0].position.DistanceInNm(position) > 0. {
+		n.duplicates = false
+	}
+	n.entries = append(n.entries, entry)
+	if len(n.entries) > bucketSize && !n.duplicates {
+		n.split()
+	}
+
+	this.size++
+	return entry
+}
+
+// Removes the entry (as returned by Insert) from the index, returning false if it isn't present. A leaf left empty is removed,
+// with its sibling taking the place of their parent.
+func (this *Index[T]) Delete(entry *Entry[T]) bool {
+	path := this.pathTo(entry.position)
+	n := path[len(path)-1]
+
+	for i, e := range n.entries {
+		if e == entry {
+			n.entries = append(n.entries[:i], n.entries[i+1:]...)
+			this.size--
+
+			if len(n.entries) == 0 && len(path) > 1 {
+				parent := path[len(path)-2]
+				if parent.inside == n {
+					*parent = *parent.outside
+				} else {
+					*parent = *parent.inside
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the (up to) k entries closest to the provided LatLong, ordered by increasing distance
+func (this *Index[T]) Nearest(position *ll.LatLong, k int) []*Entry[T] {
+	if k <= 0 {
+		return nil
+	}
+	search := &nearest[T]{position: position, k: k, boundNm: math.Inf(1)}
+	search.search(this.root)
+	return search.entries
+}
+
+// Returns all entries within (<=) the provided distance of the LatLong, ordered by increasing distance
+func (this *Index[T]) WithinRadius(position *ll.LatLong, radius *dist.Distance) []*Entry[T] {
+
+	var entries []*Entry[T]
+	var distances []float64
+
+	this.root.within(position, radius.InNauticalMiles(), func(e *Entry[T], d float64) {
+		entries, distances = append(entries, e), append(distances, d)
+	})
+
+	sort.Sort(byDistance[T]{entries, distances})
+	return entries
+}
+
+// Returns all entries falling within the provided BoundingBox, in no particular order
+func (this *Index[T]) InBoundingBox(box *ll.BoundingBox) []*Entry[T] {
+
+	center, radiusNm := boundingCircle(box)
+
+	var entries []*Entry[T]
+	this.root.within(center, radiusNm, func(e *Entry[T], _ float64) {
+		if box.Contains(e.position) {
+			entries = append(entries, e)
+		}
+	})
+	return entries
+}
+
+// Descends the tree following the path an entry at the provided position would have been inserted along, returning the nodes
+// from the root to the leaf
+func (this *Index[T]) pathTo(position *ll.LatLong) []*node[T] {
+	path := []*node[T]{this.root}
+	for n := this.root; !n.isLeaf(); path = append(path, n) {
+		if n.vantage.DistanceInNm(position) <= n.radiusNm {
+			n = n.inside
+		} else {
+			n = n.outside
+		}
+	}
+	return path
+}
+
+// Converts a leaf into an internal node, splitting its entries around the median distance from a vantage point. Leaves whose
+// entries are all at the same position can't be separated, so are left as is and marked as duplicates.
+func (this *node[T]) split() {
+
+	// Entries far from an arbitrary one make for better vantage points than the arbitrary one itself
+	vantage, farthest := this.entries[0].position, -1.
+	for _, e := range this.entries {
+		if d := this.entries[0].position.DistanceInNm(e.position); d > farthest {
+			vantage, farthest = e.position, d
+		}
+	}
+
+	distances := make([]float64, len(this.entries))
+	for i, e := range this.entries {
+		distances[i] = vantage.DistanceInNm(e.position)
+	}
+	sorted := append([]float64(nil), distances...)
+	sort.Float64s(sorted)
+	radiusNm := sorted[(len(sorted)-1)/2]
+
+	// When the median is also the farthest distance (e.g. most entries share a position) split off the farthest ones instead,
+	// which is only impossible if every entry is at the vantage point
+	if radiusNm == sorted[len(sorted)-1] {
+		i := sort.SearchFloat64s(sorted, radiusNm)
+		if i == 0 {
+			this.duplicates = true
+			return
+		}
+		radiusNm = sorted[i-1]
+	}
+
+	inside, outside := &node[T]{}, &node[T]{}
+	for i, e := range this.entries {
+		if distances[i] <= radiusNm {
+			inside.entries = append(inside.entries, e)
+		} else {
+			outside.entries = append(outside.entries, e)
+		}
+	}
+	this.vantage, this.radiusNm, this.inside, this.outside, this.entries = vantage, radiusNm, inside, outside, nil
+}
+
+// Visits every entry within the provided distance of the position along with its distance
+func (this *node[T]) within(position *ll.LatLong, radiusNm float64, visit func(*Entry[T], float64)) {
+	if this.isLeaf() {
+		for _, e := range this.entries {
+			if d := position.DistanceInNm(e.position); d <= radiusNm {
+				visit(e, d)
+			}
+		}
+		return
+	}
+
+	d := this.vantage.DistanceInNm(position)
+	if d-radiusNm <= this.radiusNm+pruneToleranceNm {
+		this.inside.within(position, radiusNm, visit)
+	}
+	if d+radiusNm >= this.radiusNm-pruneToleranceNm {
+		this.outside.within(position, radiusNm, visit)
+	}
+}
+
+// Accumulates the k closest entries to a position, ordered by increasing distance
+type nearest[T any] struct {
+	position  *ll.LatLong
+	k         int
+	entries   []*Entry[T]
+	distances []float64
+	// Distance to the kth closest entry found so far, beyond which entries needn't be considered
+	boundNm float64
+}
+
+func (this *nearest[T]) search(n *node[T]) {
+	if n.isLeaf() {
+		for _, e := range n.entries {
+			this.offer(e, this.position.DistanceInNm(e.position))
+		}
+		return
+	}
+
+	d := n.vantage.DistanceInNm(this.position)
+
+	inside := func() {
+		if d-this.boundNm <= n.radiusNm+pruneToleranceNm {
+			this.search(n.inside)
+		}
+	}
+	outside := func() {
+		if d+this.boundNm >= n.radiusNm-pruneToleranceNm {
+			this.search(n.outside)
+		}
+	}
+
+	// Search the side containing the position first, as it likely tightens the bound the most
+	if d <= n.radiusNm {
+		inside()
+		outside()
+	} else {
+		outside()
+		inside()
+	}
+}
+
+func (this *nearest[T]) offer(entry *Entry[T], distanceNm float64) {
+	if len(this.entries) == this.k && distanceNm >= this.boundNm {
+		return
+	}
+
+	i := sort.SearchFloat64s(this.distances, distanceNm)
+	for i < len(this.distances) && this.distances[i] == distanceNm {
+		i++
+	}
+
+	this.entries = append(this.entries[:i], append([]*Entry[T]{entry}, this.entries[i:]...)...)
+	this.distances = append(this.distances[:i], append([]float64{distanceNm}, this.distances[i:]...)...)
+
+	if len(this.entries) > this.k {
+		this.entries, this.distances = this.entries[:this.k], this.distances[:this.k]
+	}
+	if len(this.entries) == this.k {
+		this.boundNm = this.distances[this.k-1]
+	}
+}
+
+type byDistance[T any] struct {
+	entries   []*Entry[T]
+	distances []float64
+}
+
+func (this byDistance[T]) Len() int {
+	return len(this.entries)
+}
+
+func (this byDistance[T]) Less(i, j int) bool {
+	return this.distances[i] < this.distances[j]
+}
+
+func (this byDistance[T]) Swap(i, j int) {
+	this.entries[i], this.entries[j] = this.entries[j], this.entries[i]
+	this.distances[i], this.distances[j] = this.distances[j], this.distances[i]
+}
+
+// Returns a circle containing the BoundingBox, used to prune the tree before checking entries against the box itself.
+//
+// Any point in the box is within half its height of the box's central meridian (travelling along its own meridian) and from
+// there within the length of the parallel it lies on to the point, which is longest at the latitude closest to the equator.
+func boundingCircle(box *ll.BoundingBox) (*ll.LatLong, float64) {
+
+	width := box.East() - box.West()
+	if width < 0. {
+		width += 360.
+	}
+
+	center := ll.Normalized((box.South()+box.North())/2., box.West()+width/2.)
+
+	closestToEquator := 0.
+	if box.South() > 0. || box.North() < 0. {
+		closestToEquator = math.Min(math.Abs(box.South()), math.Abs(box.North()))
+	}
+
+	halfHeight := (box.North() - box.South()) / 2. * math.Pi / 180.
+	halfWidth := width / 2. * math.Pi / 180.
+
+	radians := math.Min(math.Pi, halfHeight+math.Cos(closestToEquator*math.Pi/180.)*halfWidth)
+	return center, radians * sph.EarthRadiusNm
+}
@@ -0,0 +1,216 @@
+package index_test
+
+import (
+	"math/rand"
+	dist "stellarsunset/spherical/distance"
+	"stellarsunset/spherical/index"
+	ll "stellarsunset/spherical/latlong"
+	"testing"
+)
+
+func isTrue(t *testing.T, condition bool, s string) {
+	if !condition {
+		t.Error(s)
+	}
+}
+
+func isEqual(t *testing.T, expected, actual any) {
+	if expected != actual {
+		t.Errorf("want = %+v, got = %+v", expected, actual)
+	}
+}
+
+func randomLatLongs(r *rand.Rand, n int) []*ll.LatLong {
+	lls := make([]*ll.LatLong, n)
+	for i := range lls {
+		lls[i] = ll.NewLatLong(r.Float64()*179.-89.5, r.Float64()*359.-179.5)
+	}
+	return lls
+}
+
+func payloads(entries []*index.Entry[int]) map[int]bool {
+	set := map[int]bool{}
+	for _, e := range entries {
+		set[e.Payload()] = true
+	}
+	return set
+}
+
+// Build an index over random points along with the points themselves for brute force comparison
+func newIndex(r *rand.Rand, n int) (*index.Index[int], []*ll.LatLong) {
+	positions := randomLatLongs(r, n)
+	idx := index.NewIndex[int]()
+	for i, p := range positions {
+		idx.Insert(p, i)
+	}
+	return idx, positions
+}
+
+func TestWithinRadius(t *testing.T) {
+
+	r := rand.New(rand.NewSource(1))
+	idx, positions := newIndex(r, 2000)
+	isEqual(t, 2000, idx.Size())
+
+	radius := dist.OfNauticalMiles(600.)
+	for _, q := range randomLatLongs(r, 50) {
+		found := idx.WithinRadius(q, radius)
+
+		expected := map[int]bool{}
+		for i, p := range positions {
+			if p.IsWithin(radius, q) {
+				expected[i] = true
+			}
+		}
+
+		isEqual(t, len(expected), len(found))
+		for i := range payloads(found) {
+			isTrue(t, expected[i], "Unexpected entry")
+		}
+		for i := 1; i < len(found); i++ {
+			isTrue(t, q.DistanceInNm(found[i-1].Position()) <= q.DistanceInNm(found[i].Position()), "Ordered")
+		}
+	}
+}
+
+func TestNearest(t *testing.T) {
+
+	r := rand.New(rand.NewSource(2))
+	idx, positions := newIndex(r, 2000)
+
+	for _, q := range randomLatLongs(r, 50) {
+		found := idx.Nearest(q, 5)
+		isEqual(t, 5, len(found))
+
+		// Nothing should be closer than the 5th closest other than the closest 4
+		set := payloads(found)
+		bound := q.DistanceInNm(found[4].Position())
+		for i, p := range positions {
+			if !set[i] {
+				isTrue(t, q.DistanceInNm(p) >= bound, "Missed closer entry")
+			}
+		}
+	}
+
+	isEqual(t, 0, len(idx.Nearest(ll.NewLatLong(0., 0.), 0)))
+	isEqual(t, 0, len(index.NewIndex[int]().Nearest(ll.NewLatLong(0., 0.), 3)))
+}
+
+func TestInBoundingBox(t *testing.T) {
+
+	r := rand.New(rand.NewSource(3))
+	idx, positions := newIndex(r, 2000)
+
+	boxes := []*ll.BoundingBox{
+		ll.NewBoundingBox(10., 30., -20., 10.),
+		ll.NewBoundingBox(-40., -20., 160., -150.),
+		ll.NewBoundingBox(70., 90., -180., 180.),
+		ll.BoundsAround(ll.NewLatLong(-85., 0.), dist.OfNauticalMiles(600.)),
+	}
+
+	for _, box := range boxes {
+		found := payloads(idx.InBoundingBox(box))
+
+		count := 0
+		for i, p := range positions {
+			if box.Contains(p) {
+				count++
+				isTrue(t, found[i], "Missing entry")
+			}
+		}
+		isEqual(t, count, len(found))
+	}
+}
+
+func TestDelete(t *testing.T) {
+
+	idx := index.NewIndex[string]()
+
+	a := idx.Insert(ll.NewLatLong(0., 0.), "a")
+	b := idx.Insert(ll.NewLatLong(0., 0.), "b")
+	for i := 0; i < 100; i++ {
+		idx.Insert(ll.NewLatLong(float64(i%10), float64(i/10)), "filler")
+	}
+
+	isTrue(t, idx.Delete(a), "Delete a")
+	isTrue(t, !idx.Delete(a), "Delete a again")
+	isEqual(t, 101, idx.Size())
+
+	nearest := idx.Nearest(ll.NewLatLong(0., 0.), 1)
+	isEqual(t, b, nearest[0])
+}
+
+func TestDeleteMany(t *testing.T) {
+
+	r := rand.New(rand.NewSource(4))
+	idx := index.NewIndex[int]()
+
+	entries := []*index.Entry[int]{}
+	for i, p := range randomLatLongs(r, 1000) {
+		entries = append(entries, idx.Insert(p, i))
+	}
+
+	// Delete the even entries
+	for i := 0; i < len(entries); i += 2 {
+		isTrue(t, idx.Delete(entries[i]), "Delete")
+	}
+	isEqual(t, 500, idx.Size())
+
+	for _, e := range idx.WithinRadius(ll.NewLatLong(0., 0.), dist.OfNauticalMiles(20000.)) {
+		isTrue(t, e.Payload()%2 == 1, "Deleted entry returned")
+	}
+	isEqual(t, 500, len(idx.WithinRadius(ll.NewLatLong(0., 0.), dist.OfNauticalMiles(20000.))))
+}
+
+func TestDuplicates(t *testing.T) {
+
+	r := rand.New(rand.NewSource(5))
+	idx := index.NewIndex[int]()
+
+	// A leaf of one position grows without splitting, as does one where most entries share the farthest position
+	for i := 0; i < 1000; i++ {
+		idx.Insert(ll.NewLatLong(10., 10.), i)
+	}
+	for i := 0; i < 9; i++ {
+		idx.Insert(ll.NewLatLong(-10., -10.), 1000+i)
+	}
+	for i, p := range randomLatLongs(r, 200) {
+		idx.Insert(p, 2000+i)
+	}
+	isEqual(t, 1209, idx.Size())
+
+	isEqual(t, 1000, len(idx.WithinRadius(ll.NewLatLong(10., 10.), dist.OfNauticalMiles(0.))))
+	isEqual(t, 9, len(idx.WithinRadius(ll.NewLatLong(-10., -10.), dist.OfNauticalMiles(0.))))
+	isEqual(t, 1209, len(idx.WithinRadius(ll.NewLatLong(0., 0.), dist.OfNauticalMiles(20000.))))
+
+	nearest := idx.Nearest(ll.NewLatLong(10.1, 10.), 1001)
+	for _, e := range nearest[:1000] {
+		isTrue(t, e.Payload() < 1000, "Duplicates are nearest")
+	}
+}
+
+func TestDeleteAll(t *testing.T) {
+
+	r := rand.New(rand.NewSource(6))
+	idx := index.NewIndex[int]()
+
+	positions := randomLatLongs(r, 500)
+	entries := []*index.Entry[int]{}
+	for i, p := range positions {
+		entries = append(entries, idx.Insert(p, i))
+	}
+
+	// Emptied leaves are removed as entries are deleted, leaving the remaining entries reachable
+	for i, e := range entries {
+		isTrue(t, idx.Delete(e), "Delete")
+		isEqual(t, len(entries)-i-1, len(idx.WithinRadius(ll.NewLatLong(0., 0.), dist.OfNauticalMiles(20000.))))
+	}
+	isEqual(t, 0, idx.Size())
+	isEqual(t, 0, len(idx.Nearest(ll.NewLatLong(0., 0.), 1)))
+
+	for i, p := range positions {
+		idx.Insert(p, i)
+	}
+	nearest := idx.Nearest(positions[42], 1)
+	isEqual(t, 42, nearest[0].Payload())
+}
@@ -0,0 +1,44 @@
+package spherical
+
+import (
+	"math"
+	vec "stellarsunset/spherical/vector"
+)
+
+// Tolerance on the sine of the angle between two points below which they are considered exactly antipodal
+const antipodalTolerance float64 = 1e-15
+
+// Compute the (latitude, longitude) of the point the provided fraction of the way along the Great Circle from the start to the
+// end location, fractions outside [0, 1] extrapolate beyond the endpoints along the same Great Circle.
+//
+// The computation is performed with vectors so that it remains accurate for long and nearly antipodal legs, when the two points
+// are exactly antipodal the Great Circle leaving the start on CourseInDegrees is used.
+func IntermediatePoint(startLat, startLon, endLat, endLon, fraction float64) (latitude, longitude float64) {
+
+	a, b := toNVector(startLat, startLon), toNVector(endLat, endLon)
+
+	// Normal to the Great Circle, whose magnitude is sin(angle)
+	n := a.Cross(b)
+	sinAngle := n.Norm()
+	angle := math.Atan2(sinAngle, a.Dot(b))
+
+	if angle == 0. {
+		return startLat, startLon
+	}
+
+	// Unit vector tangent to the Great Circle at the start pointing towards the end
+	var d *vec.Vec3
+	if sinAngle < antipodalTolerance {
+		d = direction(startLat, startLon, CourseInDegrees(startLat, startLon, endLat, endLon))
+	} else {
+		d = n.Cross(a).Times(1. / sinAngle)
+	}
+
+	sinStep, cosStep := math.Sincos(fraction * angle)
+	return fromNVector(a.Times(cosStep).Plus(d.Times(sinStep)))
+}
+
+// Compute the (latitude, longitude) of the point half way along the Great Circle from the start to the end location
+func Midpoint(startLat, startLon, endLat, endLon float64) (latitude, longitude float64) {
+	return IntermediatePoint(startLat, startLon, endLat, endLon, .5)
+}
@@ -0,0 +1,68 @@
+package spherical_test
+
+import (
+	sph "stellarsunset/spherical"
+	"testing"
+)
+
+func TestIntermediatePointEndpoints(t *testing.T) {
+
+	lat, lon := sph.IntermediatePoint(10., 20., -30., 140., 0.)
+	withinError(t, 10., lat, 1e-9, "Start Latitude")
+	withinError(t, 20., lon, 1e-9, "Start Longitude")
+
+	lat, lon = sph.IntermediatePoint(10., 20., -30., 140., 1.)
+	withinError(t, -30., lat, 1e-9, "End Latitude")
+	withinError(t, 140., lon, 1e-9, "End Longitude")
+}
+
+func TestIntermediatePointAlongEquator(t *testing.T) {
+
+	lat, lon := sph.IntermediatePoint(0., 0., 0., 40., .25)
+	withinError(t, 0., lat, 1e-9, "Latitude")
+	withinError(t, 10., lon, 1e-9, "Longitude")
+}
+
+func TestIntermediatePointSamePoint(t *testing.T) {
+
+	lat, lon := sph.IntermediatePoint(10., 10., 10., 10., .5)
+	withinError(t, 10., lat, 1e-9, "Latitude")
+	withinError(t, 10., lon, 1e-9, "Longitude")
+}
+
+func TestIntermediatePointDistances(t *testing.T) {
+
+	total := sph.DistanceInNm(40.7128, -74.0060, 35.6764, 139.6500)
+	lat, lon := sph.IntermediatePoint(40.7128, -74.0060, 35.6764, 139.6500, .3)
+
+	withinError(t, .3*total, sph.DistanceInNm(40.7128, -74.0060, lat, lon), 1e-6, "First Leg")
+	withinError(t, .7*total, sph.DistanceInNm(lat, lon, 35.6764, 139.6500), 1e-6, "Second Leg")
+}
+
+func TestIntermediatePointNearlyAntipodal(t *testing.T) {
+
+	// The path between these points passes very close to the north pole
+	lat, lon := sph.Midpoint(0., 0., 1e-7, 180.)
+
+	withinError(t, 90., lat, 1e-5, "Latitude")
+
+	// Haversine distances lose some precision near antipodal points
+	total := sph.DistanceInNm(0., 0., 1e-7, 180.)
+	withinError(t, total/2., sph.DistanceInNm(0., 0., lat, lon), 1e-4, "First Leg")
+}
+
+func TestIntermediatePointAntipodal(t *testing.T) {
+
+	lat, lon := sph.Midpoint(0., 0., 0., 180.)
+
+	total := sph.DistanceInNm(0., 0., 0., 180.)
+	withinError(t, total/2., sph.DistanceInNm(0., 0., lat, lon), 1e-6, "First Leg")
+	withinError(t, total/2., sph.DistanceInNm(lat, lon, 0., 180.), 1e-6, "Second Leg")
+}
+
+func TestIntermediatePointAcrossAntimeridian(t *testing.T) {
+
+	lat, lon := sph.Midpoint(0., 170., 0., -170.)
+	withinError(t, 0., lat, 1e-9, "Latitude")
+	withinError(t, -180., lon, 1e-9, "Longitude")
+}
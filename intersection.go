@@ -0,0 +1,71 @@
+package spherical
+
+import (
+	"math"
+)
+
+const (
+	// Tolerance on the magnitude of the cross product of two great circle normals below which they are considered coincident
+	coincidentTolerance float64 = 1e-12
+	// Tolerance relative to a segment's length used when checking whether a point lies within its extents, so the slack allowed
+	// for round-off grows with the segment (millimeters on segments thousands of NM long) rather than being fixed
+	onSegmentTolerance float64 = 1e-10
+)
+
+// Compute the two (antipodal) points at which the Great Circles defined by the provided (latitude, longitude, course) triples
+// intersect, the first intersection returned is the one closest to the first location.
+//
+// Returns ErrCoincidentGreatCircles if both paths lie along the same Great Circle (regardless of direction).
+func Intersection(lat1, lon1, course1, lat2, lon2, course2 float64) (latitude, longitude, antipodalLatitude, antipodalLongitude float64, err error) {
+
+	i := greatCircleNormal(lat1, lon1, course1).Cross(greatCircleNormal(lat2, lon2, course2))
+	if i.Norm() < coincidentTolerance {
+		return math.NaN(), math.NaN(), math.NaN(), math.NaN(), ErrCoincidentGreatCircles
+	}
+
+	latitude, longitude = fromNVector(i)
+	antipodalLatitude, antipodalLongitude = fromNVector(i.Negate())
+
+	if DistanceInNm(lat1, lon1, latitude, longitude) > DistanceInNm(lat1, lon1, antipodalLatitude, antipodalLongitude) {
+		latitude, longitude, antipodalLatitude, antipodalLongitude = antipodalLatitude, antipodalLongitude, latitude, longitude
+	}
+	return latitude, longitude, antipodalLatitude, antipodalLongitude, nil
+}
+
+// Compute the point at which the Great Circle segment between (lat1, lon1) and (lat2, lon2) crosses the Great Circle segment
+// between (lat3, lon3) and (lat4, lon4).
+//
+// The returned location is whichever of the two Great Circle intersections is closest to the middle of the first segment and
+// withinSegments reports whether it falls within the extents of both segments.
+func SegmentIntersection(lat1, lon1, lat2, lon2, lat3, lon3, lat4, lon4 float64) (latitude, longitude float64, withinSegments bool, err error) {
+
+	course1, course2 := CourseInDegrees(lat1, lon1, lat2, lon2), CourseInDegrees(lat3, lon3, lat4, lon4)
+
+	iLat, iLon, aLat, aLon, err := Intersection(lat1, lon1, course1, lat3, lon3, course2)
+	if err != nil {
+		return iLat, iLon, false, err
+	}
+
+	midLat, midLon := ProjectOut(lat1, lon1, course1, DistanceInNm(lat1, lon1, lat2, lon2)/2.)
+	if DistanceInNm(midLat, midLon, iLat, iLon) > DistanceInNm(midLat, midLon, aLat, aLon) {
+		iLat, iLon = aLat, aLon
+	}
+
+	withinSegments = isOnSegment(lat1, lon1, lat2, lon2, iLat, iLon) && isOnSegment(lat3, lon3, lat4, lon4, iLat, iLon)
+	return iLat, iLon, withinSegments, nil
+}
+
+// A point on a segment's Great Circle lies within the segment if its along track distance from the start is between zero and the
+// segment's length
+func isOnSegment(startLat, startLon, endLat, endLon, posLat, posLon float64) bool {
+
+	length := DistanceInNm(startLat, startLon, endLat, endLon)
+	ctd := CrossTrackDistanceNm(startLat, startLon, endLat, endLon, posLat, posLon)
+	atd, err := alongTrackDistanceNmE(startLat, startLon, endLat, endLon, posLat, posLon, ctd)
+	if err != nil {
+		return false
+	}
+
+	slack := onSegmentTolerance * math.Max(length, 1.)
+	return -slack <= atd && atd <= length+slack
+}
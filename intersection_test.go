@@ -0,0 +1,139 @@
+package spherical_test
+
+import (
+	"errors"
+	"math"
+	sph "stellarsunset/spherical"
+	"testing"
+)
+
+func toRadians(degrees float64) float64 {
+	return degrees * math.Pi / 180.
+}
+
+func TestIntersection(t *testing.T) {
+
+	// A path due east along the equator and a path due north along the prime meridian
+	lat, lon, antiLat, antiLon, err := sph.Intersection(0., -10., 90., 10., 0., 180.)
+
+	if err != nil {
+		t.Fatalf("Intersection(...) returned error: %v", err)
+	}
+
+	withinError(t, 0., lat, 1e-9, "Latitude")
+	withinError(t, 0., lon, 1e-9, "Longitude")
+	withinError(t, 0., antiLat, 1e-9, "AntipodalLatitude")
+	withinError(t, -180., antiLon, 1e-9, "AntipodalLongitude")
+}
+
+func TestIntersectionClosestFirst(t *testing.T) {
+
+	// Same paths as above but starting near the antimeridian
+	lat, lon, _, _, err := sph.Intersection(0., 170., 90., 10., 0., 180.)
+
+	if err != nil {
+		t.Fatalf("Intersection(...) returned error: %v", err)
+	}
+
+	withinError(t, 0., lat, 1e-9, "Latitude")
+	withinError(t, -180., lon, 1e-9, "Longitude")
+}
+
+func TestIntersectionObliquePaths(t *testing.T) {
+
+	// Reference values from http://www.movable-type.co.uk/scripts/latlong.html
+	lat, lon, _, _, err := sph.Intersection(51.8853, 0.2545, 108.547, 49.0034, 2.5735, 32.435)
+
+	if err != nil {
+		t.Fatalf("Intersection(...) returned error: %v", err)
+	}
+
+	withinError(t, 50.9078, lat, 1e-4, "Latitude")
+	withinError(t, 4.5084, lon, 1e-4, "Longitude")
+}
+
+func TestIntersectionIsOnBothPaths(t *testing.T) {
+
+	lat, lon, _, _, _ := sph.Intersection(10., 20., 30., -5., 40., 300.)
+
+	// The intersection may lie ahead of or behind each starting point
+	withinError(t, 0., math.Sin(toRadians(sph.CourseInDegrees(10., 20., lat, lon)-30.)), 1e-9, "Course1")
+	withinError(t, 0., math.Sin(toRadians(sph.CourseInDegrees(-5., 40., lat, lon)-300.)), 1e-9, "Course2")
+}
+
+func TestIntersectionCoincident(t *testing.T) {
+
+	_, _, _, _, err := sph.Intersection(0., 0., 90., 0., 10., 270.)
+
+	if !errors.Is(err, sph.ErrCoincidentGreatCircles) {
+		t.Errorf("Intersection(...) error = %v, want %v", err, sph.ErrCoincidentGreatCircles)
+	}
+}
+
+func TestSegmentIntersectionWithin(t *testing.T) {
+
+	lat, lon, within, err := sph.SegmentIntersection(0., -1., 0., 1., -1., 0., 1., 0.)
+
+	if err != nil {
+		t.Fatalf("SegmentIntersection(...) returned error: %v", err)
+	}
+
+	withinError(t, 0., lat, 1e-9, "Latitude")
+	withinError(t, 0., lon, 1e-9, "Longitude")
+	if !within {
+		t.Error("Expected intersection to be within both segments")
+	}
+}
+
+func TestSegmentIntersectionOutside(t *testing.T) {
+
+	// The second segment stops short of the equator
+	lat, lon, within, err := sph.SegmentIntersection(0., -1., 0., 1., 1., 0., 2., 0.)
+
+	if err != nil {
+		t.Fatalf("SegmentIntersection(...) returned error: %v", err)
+	}
+
+	withinError(t, 0., lat, 1e-9, "Latitude")
+	withinError(t, 0., lon, 1e-9, "Longitude")
+	if within {
+		t.Error("Expected intersection to be outside the second segment")
+	}
+}
+
+func TestSegmentIntersectionAtSharedEndpoint(t *testing.T) {
+
+	// Segments thousands of NM long meeting at an endpoint cross there
+	for _, s := range [][6]float64{{0., 0., 40., 60., -10., 100.}, {-30., -170., 50., 20., -20., 60.}, {10., 10., 60., -120., -40., -60.}} {
+		lat, lon, within, err := sph.SegmentIntersection(s[0], s[1], s[2], s[3], s[2], s[3], s[4], s[5])
+
+		if err != nil {
+			t.Fatalf("SegmentIntersection(...) returned error: %v", err)
+		}
+		withinError(t, 0., sph.DistanceInNm(s[2], s[3], lat, lon), 1e-9, "Shared endpoint")
+		if !within {
+			t.Errorf("Expected intersection at the shared endpoint %v to be within both segments", s)
+		}
+	}
+
+	// But not when the second segment starts a few meters beyond the end of the first
+	course := sph.CourseInDegrees(40., 60., 0., 0.) + 180.
+	lat, lon := sph.ProjectOut(40., 60., course, .002)
+
+	_, _, within, err := sph.SegmentIntersection(0., 0., 40., 60., lat, lon, -10., 100.)
+	if err != nil {
+		t.Fatalf("SegmentIntersection(...) returned error: %v", err)
+	}
+	if within {
+		t.Error("Expected intersection beyond the end of the first segment to be outside it")
+	}
+}
+
+func TestSegmentIntersectionCoincident(t *testing.T) {
+
+	_, _, _, err := sph.SegmentIntersection(0., 0., 0., 1., 0., 2., 0., 3.)
+
+	if !errors.Is(err, sph.ErrCoincidentGreatCircles) {
+		t.Errorf("SegmentIntersection(...) error = %v, want %v", err, sph.ErrCoincidentGreatCircles)
+	}
+}
@@ -0,0 +1,158 @@
+package latlong
+
+import (
+	"math"
+	sph "stellarsunset/spherical"
+	dist "stellarsunset/spherical/distance"
+)
+
+// A BoundingBox is a latitude/longitude aligned region, typically used to cheaply pre-filter candidates (e.g. in a database
+// query) before performing exact distance checks.
+//
+// Boxes crossing the antimeridian have a West edge greater than their East edge, see CrossesAntimeridian and Split. Boxes
+// expanded past a pole span all longitudes, West = -180 and East = 180.
+type BoundingBox struct {
+	south float64
+	north float64
+	west  float64
+	east  float64
+}
+
+// Creates a new BoundingBox from its edges in degrees, latitudes are clamped to [-90, 90] and the box spans eastwards from
+// the West edge to the East edge, so a box whose East edge is west of its West edge crosses the antimeridian.
+//
+// The West edge is wrapped into [-180, 180) and the East edge into [-180, 180], with boxes spanning 360 degrees or more
+// covering all longitudes.
+func NewBoundingBox(south, north, west, east float64) *BoundingBox {
+	south, north = math.Max(-90., south), math.Min(90., north)
+	if east-west >= 360. {
+		return &BoundingBox{south, north, -180., 180.}
+	}
+	width := eastwardOffset(west, east)
+	west = normalizeLongitude(west)
+	if east = west + width; east > 180. {
+		east -= 360.
+	}
+	return &BoundingBox{south, north, west, east}
+}
+
+// Returns the smallest BoundingBox containing every LatLong within the provided distance of the center.
+//
+// See Matuschek, "Finding Points Within a Distance of a Latitude/Longitude Using Bounding Coordinates".
+func BoundsAround(center *LatLong, radius *dist.Distance) *BoundingBox {
+	return NewBoundingBox(center.latitude, center.latitude, center.longitude, center.longitude).Expand(radius)
+}
+
+func (this *BoundingBox) South() float64 {
+	return this.south
+}
+
+func (this *BoundingBox) North() float64 {
+	return this.north
+}
+
+func (this *BoundingBox) West() float64 {
+	return this.west
+}
+
+func (this *BoundingBox) East() float64 {
+	return this.east
+}
+
+// Returns true if the box wraps across the antimeridian, i.e. its West edge is greater than its East edge
+func (this *BoundingBox) CrossesAntimeridian() bool {
+	return this.west > this.east
+}
+
+func (this *BoundingBox) IncludesNorthPole() bool {
+	return this.north == 90.
+}
+
+func (this *BoundingBox) IncludesSouthPole() bool {
+	return this.south == -90.
+}
+
+// Splits a box crossing the antimeridian into its western and eastern halves, each of which has West <= East, for use in
+// queries that can't express wrapped longitude ranges. Other boxes are returned as is.
+func (this *BoundingBox) Split() []*BoundingBox {
+	if !this.CrossesAntimeridian() {
+		return []*BoundingBox{this}
+	}
+	return []*BoundingBox{{this.south, this.north, this.west, 180.}, {this.south, this.north, -180., this.east}}
+}
+
+// Returns true if the LatLong falls within (or on the edge of) the box
+func (this *BoundingBox) Contains(position *LatLong) bool {
+	return this.south <= position.latitude && position.latitude <= this.north &&
+		eastwardOffset(this.west, position.longitude) <= this.width()
+}
+
+// Returns true if the two boxes share any point
+func (this *BoundingBox) Intersects(that *BoundingBox) bool {
+	return this.south <= that.north && that.south <= this.north &&
+		(eastwardOffset(this.west, that.west) <= this.width() || eastwardOffset(that.west, this.west) <= that.width())
+}
+
+// Returns the smallest BoundingBox containing both boxes
+func (this *BoundingBox) Union(that *BoundingBox) *BoundingBox {
+
+	south, north := math.Min(this.south, that.south), math.Max(this.north, that.north)
+
+	// Of the longitude ranges covering both boxes pick the narrowest, or all longitudes if none do
+	candidates := []*BoundingBox{
+		{south, north, this.west, this.east},
+		{south, north, that.west, that.east},
+		{south, north, this.west, that.east},
+		{south, north, that.west, this.east},
+	}
+
+	var union *BoundingBox
+	for _, c := range candidates {
+		if c.coversLongitudes(this) && c.coversLongitudes(that) && (union == nil || c.width() < union.width()) {
+			union = c
+		}
+	}
+	if union == nil {
+		return NewBoundingBox(south, north, -180., 180.)
+	}
+	return NewBoundingBox(union.south, union.north, union.west, union.east)
+}
+
+// Returns the smallest BoundingBox containing every LatLong within the provided distance of this box. Boxes expanded past a
+// pole include it and span all longitudes.
+func (this *BoundingBox) Expand(distance *dist.Distance) *BoundingBox {
+
+	r := distance.InNauticalMiles() / sph.EarthRadiusNm
+	south, north := this.south-toDegrees(r), this.north+toDegrees(r)
+
+	if south <= -90. || north >= 90. {
+		return NewBoundingBox(south, north, -180., 180.)
+	}
+
+	// The widest longitude offset within the radius of a point is at the latitude of the box edge nearest the pole
+	phi := toRadians(math.Max(math.Abs(this.south), math.Abs(this.north)))
+	dLon := toDegrees(math.Asin(math.Min(1., math.Sin(r)/math.Cos(phi))))
+
+	return NewBoundingBox(south, north, this.west-dLon, this.west+this.width()+dLon)
+}
+
+// The extent of the box in longitude in degrees, in [0, 360]
+func (this *BoundingBox) width() float64 {
+	if this.east-this.west == 360. {
+		return 360.
+	}
+	return eastwardOffset(this.west, this.east)
+}
+
+func (this *BoundingBox) coversLongitudes(that *BoundingBox) bool {
+	return eastwardOffset(this.west, that.west)+that.width() <= this.width()
+}
+
+// The number of degrees east of the first longitude the second is, in [0, 360)
+func eastwardOffset(from, to float64) float64 {
+	offset := math.Mod(to-from, 360.)
+	if offset < 0. {
+		offset += 360.
+	}
+	return offset
+}
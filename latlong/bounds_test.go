@@ -0,0 +1,155 @@
+package latlong_test
+
+import (
+	"math"
+	dist "stellarsunset/spherical/distance"
+	ll "stellarsunset/spherical/latlong"
+	"testing"
+)
+
+func TestNewBoundingBox(t *testing.T) {
+
+	// Latitudes are clamped, touching a pole doesn't widen the box to all longitudes
+	a := ll.NewBoundingBox(80., 95., 10., 20.)
+	isEqual(t, 90., a.North())
+	isEqual(t, 10., a.West())
+	isEqual(t, 20., a.East())
+
+	// The West edge wraps into [-180, 180) and the East edge follows it eastwards, ending at 180 rather than wrapping to -180
+	b := ll.NewBoundingBox(0., 1., 190., 200.)
+	isEqual(t, -170., b.West())
+	isEqual(t, -160., b.East())
+
+	c := ll.NewBoundingBox(0., 1., -190., -180.)
+	isEqual(t, 170., c.West())
+	isEqual(t, 180., c.East())
+	isFalse(t, c.CrossesAntimeridian(), "Ending at antimeridian")
+
+	d := ll.NewBoundingBox(0., 1., 170., -170.)
+	isTrue(t, d.CrossesAntimeridian(), "Crossing antimeridian")
+
+	// Spanning a full turn covers all longitudes
+	e := ll.NewBoundingBox(0., 1., -100., 260.)
+	isEqual(t, -180., e.West())
+	isEqual(t, 180., e.East())
+}
+
+func TestBoundsAround(t *testing.T) {
+
+	center := ll.NewLatLong(40., -75.)
+	radius := dist.OfNauticalMiles(60.)
+
+	box := ll.BoundsAround(center, radius)
+	isFalse(t, box.CrossesAntimeridian(), "Antimeridian")
+
+	// One degree of latitude is (very nearly) 60NM
+	withinError(t, 39., box.South(), 1e-2)
+	withinError(t, 41., box.North(), 1e-2)
+
+	// Every point at (just inside, to allow for round-off) the radius should fall within the box
+	for course := 0.; course < 360.; course += 5. {
+		p := center.ProjectOut(course, 60.-1e-9)
+		isTrue(t, box.Contains(p), "Contains edge")
+	}
+	isFalse(t, box.Contains(center.ProjectOut(0., 61.)), "North of box")
+
+	// And the box should be tight, touching the circle at its easternmost point
+	maxLon := -180.
+	for course := 0.; course < 180.; course += .01 {
+		maxLon = math.Max(maxLon, center.ProjectOut(course, 60.).Longitude())
+	}
+	withinError(t, box.East(), maxLon, 1e-6)
+	isFalse(t, box.Contains(ll.NewLatLong(40., -73.)), "East of box")
+}
+
+func TestBoundsAroundAntimeridian(t *testing.T) {
+
+	center := ll.NewLatLong(0., 179.5)
+	box := ll.BoundsAround(center, dist.OfNauticalMiles(60.))
+
+	isTrue(t, box.CrossesAntimeridian(), "Antimeridian")
+	withinError(t, 178.5, box.West(), 1e-2)
+	withinError(t, -179.5, box.East(), 1e-2)
+
+	isTrue(t, box.Contains(ll.NewLatLong(0., 179.9)), "East of center")
+	isTrue(t, box.Contains(ll.NewLatLong(0., -179.9)), "Across antimeridian")
+	isFalse(t, box.Contains(ll.NewLatLong(0., 0.)), "Prime meridian")
+	isFalse(t, box.Contains(ll.NewLatLong(0., -179.)), "East of box")
+
+	split := box.Split()
+	isEqual(t, 2, len(split))
+	isEqual(t, 180., split[0].East())
+	isEqual(t, -180., split[1].West())
+	isFalse(t, split[0].CrossesAntimeridian(), "West half")
+	isFalse(t, split[1].CrossesAntimeridian(), "East half")
+}
+
+func TestBoundsAroundPole(t *testing.T) {
+
+	box := ll.BoundsAround(ll.NewLatLong(89.5, 10.), dist.OfNauticalMiles(60.))
+
+	isTrue(t, box.IncludesNorthPole(), "North Pole")
+	isFalse(t, box.IncludesSouthPole(), "South Pole")
+	isEqual(t, -180., box.West())
+	isEqual(t, 180., box.East())
+	withinError(t, 88.5, box.South(), 1e-2)
+
+	isTrue(t, box.Contains(ll.NewLatLong(89.5, -170.)), "Across pole")
+	isEqual(t, 1, len(box.Split()))
+}
+
+func TestBoundingBoxIntersects(t *testing.T) {
+
+	a := ll.NewBoundingBox(0., 10., 170., -170.)
+	b := ll.NewBoundingBox(5., 15., -175., -160.)
+	c := ll.NewBoundingBox(5., 15., 0., 10.)
+	d := ll.NewBoundingBox(20., 30., 175., 176.)
+
+	isTrue(t, a.Intersects(b), "a, b")
+	isTrue(t, b.Intersects(a), "b, a")
+	isFalse(t, a.Intersects(c), "a, c")
+	isFalse(t, a.Intersects(d), "a, d")
+	isTrue(t, ll.BoundsAround(ll.NewLatLong(-89., 0.), dist.OfNauticalMiles(120.)).Intersects(ll.NewBoundingBox(-88., -87.5, 100., 101.)), "Pole")
+
+	// Boxes ending at the antimeridian don't cross it
+	e := ll.NewBoundingBox(0., 1., 170., 180.)
+	isFalse(t, e.CrossesAntimeridian(), "Ending at antimeridian")
+	isEqual(t, 180., e.East())
+	isFalse(t, e.Intersects(ll.NewBoundingBox(0., 1., -170., -160.)), "e, -170")
+}
+
+func TestBoundingBoxUnion(t *testing.T) {
+
+	a := ll.NewBoundingBox(0., 10., 170., 175.)
+	b := ll.NewBoundingBox(-5., 5., -175., -170.)
+
+	union := a.Union(b)
+	isEqual(t, -5., union.South())
+	isEqual(t, 10., union.North())
+	isEqual(t, 170., union.West())
+	isEqual(t, -170., union.East())
+
+	// Narrowest is the same regardless of order
+	isEqual(t, *union, *b.Union(a))
+
+	c := ll.NewBoundingBox(0., 1., -10., 10.)
+	isEqual(t, *c, *c.Union(ll.NewBoundingBox(0., 1., -5., 5.)))
+
+	// Together these cover all longitudes
+	full := ll.NewBoundingBox(0., 1., 0., -90.).Union(ll.NewBoundingBox(0., 1., -100., 10.))
+	isEqual(t, -180., full.West())
+	isEqual(t, 180., full.East())
+}
+
+func TestBoundingBoxExpand(t *testing.T) {
+
+	box := ll.NewBoundingBox(0., 1., 0., 1.).Expand(dist.OfNauticalMiles(60.))
+
+	withinError(t, -1., box.South(), 1e-2)
+	withinError(t, 2., box.North(), 1e-2)
+	isTrue(t, box.West() < -1., "West")
+	isTrue(t, box.East() > 2., "East")
+
+	global := box.Expand(dist.OfNauticalMiles(10800.))
+	isTrue(t, global.IncludesNorthPole() && global.IncludesSouthPole(), "Global")
+}
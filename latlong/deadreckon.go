@@ -0,0 +1,111 @@
+package latlong
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	sph "stellarsunset/spherical"
+	crs "stellarsunset/spherical/course"
+	spd "stellarsunset/spherical/speed"
+	"time"
+)
+
+// Returned when dead reckoning at intervals with a step that isn't positive
+var ErrInvalidStep = errors.New("Dead reckoning step must be positive")
+
+// A LatLong at a point in time, e.g. a position report or an extrapolated position
+type TimedLatLong struct {
+	time     time.Time
+	position *LatLong
+}
+
+func NewTimedLatLong(at time.Time, position *LatLong) *TimedLatLong {
+	return &TimedLatLong{at, position}
+}
+
+func (this *TimedLatLong) Time() time.Time {
+	return this.time
+}
+
+func (this *TimedLatLong) LatLong() *LatLong {
+	return this.position
+}
+
+// Extrapolates this LatLong along the great circle leaving it on the course at the speed for the elapsed time, which may be
+// negative to extrapolate backwards.
+func (this *LatLong) DeadReckon(course *crs.Course, speed *spd.Speed, elapsed time.Duration) *LatLong {
+	return this.project(course, speed.DistanceIn(elapsed))
+}
+
+// Extrapolates this LatLong leaving it on the course at the speed for the elapsed time while turning at a constant rate in degrees
+// per second, positive for turns to the right (clockwise seen from above) and negative for turns to the left, as an aircraft does
+// in a rate one turn of 3 degrees per second.
+//
+// The course changes at the turn rate relative to the great circle the LatLong is following, tracing a small circle around the
+// center of the turn. A turn rate of zero is the same as DeadReckon. As with negative times a negative speed extrapolates
+// backwards around the same turn, and when no distance is travelled the result is this LatLong.
+func (this *LatLong) DeadReckonTurning(course *crs.Course, speed *spd.Speed, turnRateDegPerSec float64,
+	elapsed time.Duration) *LatLong {
+
+	if turnRateDegPerSec == 0. {
+		return this.DeadReckon(course, speed, elapsed)
+	}
+
+	distance := speed.DistanceIn(elapsed).InNauticalMiles()
+	if distance == 0. {
+		return &LatLong{this.latitude, this.longitude}
+	}
+
+	// The angular radius of the turn, whose geodesic curvature cot(rho) / R must equal the turn rate divided by the speed
+	rate := toRadians(math.Abs(turnRateDegPerSec))
+	rho := math.Atan(math.Abs(speed.InKnots()) / 3600. / (rate * sph.EarthRadiusNm))
+
+	// The axis through the center of the turn, which lies to the right of the course for right turns
+	sign := math.Copysign(1., turnRateDegPerSec)
+	p := this.ToNVector()
+	axis := p.Times(math.Cos(rho)).Minus(this.GreatCircleNormal(course).Times(sign * math.Sin(rho)))
+
+	// The LatLong rotates about the axis at the speed, along a circle whose circumference is shrunk by sin(rho), clockwise seen
+	// from above for right turns
+	theta := -sign * distance / (sph.EarthRadiusNm * math.Sin(rho))
+	sinTheta, cosTheta := math.Sincos(theta)
+
+	rotated := p.Times(cosTheta).Plus(axis.Cross(p).Times(sinTheta)).Plus(axis.Times(axis.Dot(p) * (1. - cosTheta)))
+	return FromNVector(rotated)
+}
+
+// Extrapolates this LatLong as DeadReckonTurning does (with a turn rate of zero for a great circle), returning the positions at
+// the start time and every step after it up to and including the elapsed time. As with DeadReckon a negative elapsed time
+// extrapolates backwards, with the positions every step before the start time. Panics if DeadReckonEveryE would return an error.
+func (this *LatLong) DeadReckonEvery(start time.Time, course *crs.Course, speed *spd.Speed, turnRateDegPerSec float64,
+	step, elapsed time.Duration) []*TimedLatLong {
+
+	positions, err := this.DeadReckonEveryE(start, course, speed, turnRateDegPerSec, step, elapsed)
+	if err != nil {
+		panic(err)
+	}
+	return positions
+}
+
+// Extrapolates this LatLong at intervals as DeadReckonEvery does, or returns an error matching ErrInvalidStep if the step isn't
+// positive.
+func (this *LatLong) DeadReckonEveryE(start time.Time, course *crs.Course, speed *spd.Speed, turnRateDegPerSec float64,
+	step, elapsed time.Duration) ([]*TimedLatLong, error) {
+
+	if step <= 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidStep, step)
+	}
+
+	// Step towards the elapsed time, backwards if it's negative
+	direction := time.Duration(1)
+	if elapsed < 0 {
+		direction = -1
+	}
+
+	var positions []*TimedLatLong
+	for offset := time.Duration(0); direction*offset <= direction*elapsed; offset += direction * step {
+		at := this.DeadReckonTurning(course, speed, turnRateDegPerSec, offset)
+		positions = append(positions, NewTimedLatLong(start.Add(offset), at))
+	}
+	return positions, nil
+}
@@ -0,0 +1,117 @@
+package latlong_test
+
+import (
+	"errors"
+	"math"
+	crs "stellarsunset/spherical/course"
+	ll "stellarsunset/spherical/latlong"
+	spd "stellarsunset/spherical/speed"
+	"testing"
+	"time"
+)
+
+func TestDeadReckon(t *testing.T) {
+
+	start := ll.NewLatLong(0., 0.)
+
+	east := start.DeadReckon(crs.OfDegrees(90.), spd.OfKnots(120.), 30*time.Minute)
+	withinError(t, 60., start.DistanceInNm(east), 1e-9)
+	withinError(t, 0., east.Latitude(), 1e-9)
+	isTrue(t, east.Longitude() > 0., "East")
+
+	// Negative times extrapolate backwards
+	west := start.DeadReckon(crs.OfDegrees(90.), spd.OfKnots(120.), -30*time.Minute)
+	withinError(t, -east.Longitude(), west.Longitude(), 1e-9)
+}
+
+func TestDeadReckonTurning(t *testing.T) {
+
+	start, course, speed := ll.NewLatLong(47.45, -122.31), crs.OfDegrees(340.), spd.OfKnots(120.)
+
+	// Half a rate one turn to the right puts the aircraft a turn diameter away, abeam its starting position on the right
+	half := start.DeadReckonTurning(course, speed, 3., time.Minute)
+	diameter := 2. * (120. / 3600.) / (3. * math.Pi / 180.)
+	withinError(t, diameter, start.DistanceInNm(half), 1e-6)
+	withinError(t, 70., start.CourseInDegrees(half), 1e-3)
+
+	left := start.DeadReckonTurning(course, speed, -3., time.Minute)
+	withinError(t, 250., start.CourseInDegrees(left), 1e-3)
+
+	// A full turn returns to the start
+	full := start.DeadReckonTurning(course, speed, 3., 2*time.Minute)
+	withinError(t, 0., start.DistanceInNm(full), 1e-9)
+
+	// Gentle turns tend to the great circle
+	straight := start.DeadReckon(course, speed, 10*time.Minute)
+	withinError(t, 0., straight.DistanceInNm(start.DeadReckonTurning(course, speed, 1e-9, 10*time.Minute)), 1e-6)
+	withinError(t, 0., straight.DistanceInNm(start.DeadReckonTurning(course, speed, 0., 10*time.Minute)), 0.)
+}
+
+func TestDeadReckonTurningWithoutDistance(t *testing.T) {
+
+	start, course := ll.NewLatLong(47.45, -122.31), crs.OfDegrees(340.)
+
+	stationary := start.DeadReckonTurning(course, spd.OfKnots(0.), 3., time.Minute)
+	isEqual(t, start.Latitude(), stationary.Latitude())
+	isEqual(t, start.Longitude(), stationary.Longitude())
+
+	now := start.DeadReckonTurning(course, spd.OfKnots(120.), 3., 0)
+	isEqual(t, start.Latitude(), now.Latitude())
+	isEqual(t, start.Longitude(), now.Longitude())
+}
+
+func TestDeadReckonTurningNegativeSpeed(t *testing.T) {
+
+	start, course := ll.NewLatLong(47.45, -122.31), crs.OfDegrees(340.)
+
+	// Flying backwards around the same turn, as for a negative time
+	backwards := start.DeadReckonTurning(course, spd.OfKnots(-120.), 3., 20*time.Second)
+	earlier := start.DeadReckonTurning(course, spd.OfKnots(120.), 3., -20*time.Second)
+	withinError(t, 0., backwards.DistanceInNm(earlier), 1e-12)
+
+	// Which is a sixth of the way back around the turn, so the chord to it is 30 degrees left of the reciprocal course
+	diameter := 2. * (120. / 3600.) / (3. * math.Pi / 180.)
+	withinError(t, diameter*math.Sin(math.Pi/6.), start.DistanceInNm(backwards), 1e-6)
+	withinError(t, 130., start.CourseInDegrees(backwards), 1e-3)
+}
+
+func TestDeadReckonEvery(t *testing.T) {
+
+	start, at := ll.NewLatLong(51.47, -0.45), time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	course, speed := crs.OfDegrees(250.), spd.OfKnots(250.)
+
+	positions := start.DeadReckonEvery(at, course, speed, 1.5, 10*time.Second, time.Minute+5*time.Second)
+	isEqual(t, 7, len(positions))
+
+	isEqual(t, at, positions[0].Time())
+	withinError(t, 0., start.DistanceInNm(positions[0].LatLong()), 1e-12)
+
+	for i, p := range positions {
+		elapsed := time.Duration(i) * 10 * time.Second
+		isEqual(t, at.Add(elapsed), p.Time())
+		withinError(t, 0., start.DeadReckonTurning(course, speed, 1.5, elapsed).DistanceInNm(p.LatLong()), 1e-12)
+	}
+
+	// Negative elapsed times step backwards from the start, as DeadReckon extrapolates backwards
+	backwards := start.DeadReckonEvery(at, course, speed, 1.5, 10*time.Second, -25*time.Second)
+	isEqual(t, 3, len(backwards))
+
+	for i, p := range backwards {
+		elapsed := -time.Duration(i) * 10 * time.Second
+		isEqual(t, at.Add(elapsed), p.Time())
+		withinError(t, 0., start.DeadReckonTurning(course, speed, 1.5, elapsed).DistanceInNm(p.LatLong()), 1e-12)
+	}
+}
+
+func TestDeadReckonEveryE(t *testing.T) {
+
+	start, at := ll.NewLatLong(51.47, -0.45), time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	course, speed := crs.OfDegrees(250.), spd.OfKnots(250.)
+
+	positions, err := start.DeadReckonEveryE(at, course, speed, 0., 10*time.Second, time.Minute)
+	isTrue(t, err == nil, "Error")
+	isEqual(t, 7, len(positions))
+
+	_, err = start.DeadReckonEveryE(at, course, speed, 0., 0, time.Minute)
+	isTrue(t, errors.Is(err, ll.ErrInvalidStep), "Zero step")
+}
@@ -0,0 +1,198 @@
+package latlong
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The notations a LatLong can be formatted in, each of which can be read back by Parse
+type Style int
+
+const (
+	// Signed decimal degrees, e.g. "40.712800, -74.006000", see ParseDecimal
+	Decimal Style = iota
+	// Degrees, minutes and seconds, e.g. `40°42'46"N 74°00'22"W`, see ParseDMS
+	DMS
+	// Degrees and decimal minutes, e.g. "40°42.77'N 74°00.36'W", see ParseDDM
+	DDM
+	// Fixed width degrees, minutes and seconds as used in ICAO flight plans, e.g. "404246N0740022W", see ParseICAO
+	ICAO
+	// The ISO 6709 string representation in signed decimal degrees, e.g. "+40.7128-074.0060/", see ParseISO6709
+	ISO6709
+)
+
+var styles = [...]string{
+	Decimal: "Decimal",
+	DMS:     "DMS",
+	DDM:     "DDM",
+	ICAO:    "ICAO",
+	ISO6709: "ISO6709",
+}
+
+func (this Style) String() string {
+	return styles[this]
+}
+
+// Formats the LatLong in signed decimal degrees to six decimal places, i.e. roughly 0.1m
+func (this *LatLong) String() string {
+	return this.Format(Decimal, 6)
+}
+
+// Formats the LatLong in the provided style with the given number of decimal places on the smallest unit, e.g. seconds for
+// DMS. Values are rounded (not truncated) so parsing the result recovers the LatLong to within half of the last decimal place.
+//
+// Values that would round onto a pole or the antimeridian (which Parse rejects) are instead formatted as the closest value
+// inside the range at the precision, e.g. 89.99999999 as 89°59'59"N, so they're recovered to within one of the last decimal place.
+func (this *LatLong) Format(style Style, precision int) string {
+
+	precision = int(math.Max(0., float64(precision)))
+
+	switch style {
+	case DMS:
+		lat, lon := sexagesimal(this.latitude, 90., 3, precision), sexagesimal(this.longitude, 180., 3, precision)
+		return fmt.Sprintf(`%d°%02d'%s"%s %d°%02d'%s"%s`,
+			lat.degrees, lat.minutes, lat.last, lat.hemisphere("NS"), lon.degrees, lon.minutes, lon.last, lon.hemisphere("EW"))
+	case DDM:
+		lat, lon := sexagesimal(this.latitude, 90., 2, precision), sexagesimal(this.longitude, 180., 2, precision)
+		return fmt.Sprintf(`%d°%s'%s %d°%s'%s`, lat.degrees, lat.last, lat.hemisphere("NS"), lon.degrees, lon.last, lon.hemisphere("EW"))
+	case ICAO:
+		lat, lon := sexagesimal(this.latitude, 90., 3, precision), sexagesimal(this.longitude, 180., 3, precision)
+		return fmt.Sprintf(`%02d%02d%s%s%03d%02d%s%s`,
+			lat.degrees, lat.minutes, lat.last, lat.hemisphere("NS"), lon.degrees, lon.minutes, lon.last, lon.hemisphere("EW"))
+	case ISO6709:
+		lat, lon := sexagesimal(this.latitude, 90., 1, precision), sexagesimal(this.longitude, 180., 1, precision)
+		return fmt.Sprintf("%s%s%s%s/", lat.sign(), padded(lat.last, 2), lon.sign(), padded(lon.last, 3))
+	default:
+		lat, lon := sexagesimal(this.latitude, 90., 1, precision), sexagesimal(this.longitude, 180., 1, precision)
+		return fmt.Sprintf("%s%s, %s%s", strings.TrimPrefix(lat.sign(), "+"), lat.last, strings.TrimPrefix(lon.sign(), "+"), lon.last)
+	}
+}
+
+// A coordinate rounded and split into whole degrees, whole minutes and the remaining (formatted) last component
+type components struct {
+	negative bool
+	degrees  int
+	minutes  int
+	last     string
+}
+
+func (this components) hemisphere(hemispheres string) string {
+	if this.negative {
+		return hemispheres[1:]
+	}
+	return hemispheres[:1]
+}
+
+func (this components) sign() string {
+	if this.negative {
+		return "-"
+	}
+	return "+"
+}
+
+// Splits the coordinate into the given number of components, rounding the last of them to the provided number of decimal places
+// before splitting so the components carry correctly (e.g. to 41°00'00" rather than 40°59'60"). The magnitude is kept strictly
+// below the limit (in degrees) so the result is always in range.
+func sexagesimal(degrees, limit float64, count, precision int) components {
+
+	scale := math.Pow(10., float64(precision))
+	perDegree := math.Pow(60., float64(count-1)) * scale
+
+	// The coordinate as an integral number of the smallest unit
+	n := math.Min(math.Round(math.Abs(degrees)*perDegree), limit*perDegree-1.)
+
+	c := components{negative: degrees < 0. && n > 0.}
+	switch count {
+	case 1:
+		c.last = strconv.FormatFloat(n/scale, 'f', precision, 64)
+	case 2:
+		c.degrees = int(math.Floor(n / perDegree))
+		c.last = padded(strconv.FormatFloat((n-float64(c.degrees)*perDegree)/scale, 'f', precision, 64), 2)
+	case 3:
+		c.degrees = int(math.Floor(n / perDegree))
+		rem := n - float64(c.degrees)*perDegree
+		c.minutes = int(math.Floor(rem / (60. * scale)))
+		c.last = padded(strconv.FormatFloat((rem-float64(c.minutes)*60.*scale)/scale, 'f', precision, 64), 2)
+	}
+	return c
+}
+
+// Left pads the integer part of the formatted number with zeros to the given number of digits
+func padded(number string, digits int) string {
+	integer := len(number)
+	if i := strings.Index(number, "."); i >= 0 {
+		integer = i
+	}
+	if integer >= digits {
+		return number
+	}
+	return strings.Repeat("0", digits-integer) + number
+}
+
+var iso6709 = regexp.MustCompile(`^\s*([+-]\d+(?:\.\d+)?)([+-]\d+(?:\.\d+)?)/?\s*$`)
+
+// Parses the ISO 6709 string representation of a point, ±DD.D±DDD.D/, e.g. "+40.7128-074.0060/". The sexagesimal forms ±DDMM.M
+// and ±DDMMSS.S (with longitudes ±DDDMM.M and ±DDDMMSS.S) are accepted too, altitudes and coordinate reference systems aren't.
+func ParseISO6709(input string) (*LatLong, error) {
+
+	m := iso6709.FindStringSubmatchIndex(input)
+	if m == nil {
+		return nil, &ParseError{input, input, 0, fmt.Errorf("%w: expected ±DD.D±DDD.D/", ErrInvalidFormat)}
+	}
+
+	latitude, err := parseISO6709Coordinate(input, m[2], m[3], 2, checkLatitude)
+	if err != nil {
+		return nil, err
+	}
+	longitude, err := parseISO6709Coordinate(input, m[4], m[5], 3, checkLongitude)
+	if err != nil {
+		return nil, err
+	}
+	return &LatLong{latitude, longitude}, nil
+}
+
+func parseISO6709Coordinate(input string, start, end, degreeDigits int, check func(float64) (float64, error)) (float64, error) {
+
+	token := input[start:end]
+	fail := func(err error) (float64, error) {
+		return 0., &ParseError{input, token, start, err}
+	}
+
+	integer := len(token) - 1
+	if i := strings.Index(token, "."); i >= 0 {
+		integer = i - 1
+	}
+
+	count := (integer-degreeDigits)/2 + 1
+	if integer < degreeDigits || (integer-degreeDigits)%2 != 0 || count > 3 {
+		return fail(fmt.Errorf("%w: expected %d, %d or %d integer digits", ErrInvalidFormat, degreeDigits, degreeDigits+2, degreeDigits+4))
+	}
+
+	digits := token[1:]
+	degrees, _ := strconv.ParseFloat(digits[:degreeDigits], 64)
+	if count == 1 {
+		degrees, _ = strconv.ParseFloat(digits, 64)
+	}
+	for i := 1; i < count; i++ {
+		from, to := degreeDigits+2*(i-1), degreeDigits+2*i
+		if i == count-1 {
+			to = len(digits)
+		}
+		value, _ := strconv.ParseFloat(digits[from:to], 64)
+		if value >= 60. {
+			return fail(fmt.Errorf("%w: minutes and seconds must be less than 60", ErrInvalidFormat))
+		}
+		degrees += value / math.Pow(60., float64(i))
+	}
+
+	if token[0] == '-' {
+		degrees = -degrees
+	}
+	if _, err := check(degrees); err != nil {
+		return fail(err)
+	}
+	return degrees, nil
+}
@@ -0,0 +1,134 @@
+package latlong_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	ll "stellarsunset/spherical/latlong"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	nyc := ll.NewLatLong(40.7128, -74.0060)
+	isEqual(t, "40.712800, -74.006000", nyc.String())
+	isEqual(t, "40.712800, -74.006000", fmt.Sprint(nyc))
+}
+
+func TestFormat(t *testing.T) {
+
+	nyc := ll.NewLatLong(40.7128, -74.0060)
+
+	isEqual(t, "40.7128, -74.0060", nyc.Format(ll.Decimal, 4))
+	isEqual(t, `40°42'46"N 74°00'22"W`, nyc.Format(ll.DMS, 0))
+	isEqual(t, `40°42'46.08"N 74°00'21.60"W`, nyc.Format(ll.DMS, 2))
+	isEqual(t, "40°42.77'N 74°00.36'W", nyc.Format(ll.DDM, 2))
+	isEqual(t, "404246N0740022W", nyc.Format(ll.ICAO, 0))
+	isEqual(t, "404246.1N0740021.6W", nyc.Format(ll.ICAO, 1))
+	isEqual(t, "+40.7128-074.0060/", nyc.Format(ll.ISO6709, 4))
+
+	sydney := ll.NewLatLong(-33.8688, 151.2093)
+	isEqual(t, "-33.869, 151.209", sydney.Format(ll.Decimal, 3))
+	isEqual(t, `33°52'08"S 151°12'33"E`, sydney.Format(ll.DMS, 0))
+	isEqual(t, "335208S1511233E", sydney.Format(ll.ICAO, 0))
+	isEqual(t, "-33.87+151.21/", sydney.Format(ll.ISO6709, 2))
+}
+
+func TestFormatCarry(t *testing.T) {
+
+	// 59.9999 seconds rounds up into the minutes and degrees
+	p := ll.NewLatLong(40.+59./60.+59.9999/3600., 9.+59./60.+59.9999/3600.)
+	isEqual(t, `41°00'00"N 10°00'00"E`, p.Format(ll.DMS, 0))
+	isEqual(t, "41°00.0'N 10°00.0'E", p.Format(ll.DDM, 1))
+
+	// Values rounding to zero are in the northern and eastern hemispheres
+	z := ll.NewLatLong(-1e-9, -1e-9)
+	isEqual(t, `0°00'00"N 0°00'00"E`, z.Format(ll.DMS, 0))
+	isEqual(t, "0.00, 0.00", z.Format(ll.Decimal, 2))
+	isEqual(t, "+00.00+000.00/", z.Format(ll.ISO6709, 2))
+}
+
+func TestStyleString(t *testing.T) {
+	isEqual(t, "ICAO", ll.ICAO.String())
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+
+	// The maximum error at the given precision in degrees for each style
+	tolerances := map[ll.Style]func(int) float64{
+		ll.Decimal: func(p int) float64 { return .5 * math.Pow(10., -float64(p)) },
+		ll.DMS:     func(p int) float64 { return .5 * math.Pow(10., -float64(p)) / 3600. },
+		ll.DDM:     func(p int) float64 { return .5 * math.Pow(10., -float64(p)) / 60. },
+		ll.ICAO:    func(p int) float64 { return .5 * math.Pow(10., -float64(p)) / 3600. },
+		ll.ISO6709: func(p int) float64 { return .5 * math.Pow(10., -float64(p)) },
+	}
+
+	for lat := -89.; lat < 90.; lat += 6.37 {
+		for lon := -179.; lon < 180.; lon += 9.91 {
+			p := ll.NewLatLong(lat, lon)
+			for style, tolerance := range tolerances {
+				for precision := 0; precision < 6; precision++ {
+					s := p.Format(style, precision)
+
+					parsed, err := ll.Parse(s)
+					if err != nil {
+						t.Errorf("%s: %v", style, err)
+						continue
+					}
+
+					tol := tolerance(precision) + 1e-12
+					withinError(t, lat, parsed.Latitude(), tol)
+					withinError(t, lon, parsed.Longitude(), tol)
+
+					// Formatting the parsed value is lossless
+					isEqual(t, s, parsed.Format(style, precision))
+				}
+			}
+		}
+	}
+}
+
+func TestFormatRoundTripAtLimits(t *testing.T) {
+
+	// The maximum error at the given precision in degrees, one whole unit of the last decimal place as values that would round
+	// out of range are moved back inside it
+	units := map[ll.Style]float64{ll.Decimal: 1., ll.DMS: 3600., ll.DDM: 60., ll.ICAO: 3600., ll.ISO6709: 1.}
+
+	for _, lat := range []float64{89.99999999, -89.99999999, 89.9, 0.} {
+		for _, lon := range []float64{179.99999999, -179.99999999, 179.9, 0.} {
+			p := ll.NewLatLong(lat, lon)
+			for style, unit := range units {
+				for precision := 0; precision < 9; precision++ {
+					s := p.Format(style, precision)
+
+					parsed, err := ll.Parse(s)
+					if err != nil {
+						t.Errorf("%s: %v", style, err)
+						continue
+					}
+
+					tol := math.Pow(10., -float64(precision))/unit + 1e-12
+					withinError(t, lat, parsed.Latitude(), tol)
+					withinError(t, lon, parsed.Longitude(), tol)
+					isEqual(t, s, parsed.Format(style, precision))
+				}
+			}
+		}
+	}
+
+	isEqual(t, `89°59'59"N 179°59'59"W`, ll.NewLatLong(89.99999999, -179.99999999).Format(ll.DMS, 0))
+	isEqual(t, "-89.99, 179.99", ll.NewLatLong(-89.99999999, 179.99999999).Format(ll.Decimal, 2))
+	isEqual(t, "895959N1795959E", ll.Normalized(90., 180.-1e-9).Format(ll.ICAO, 0))
+}
+
+func TestParseISO6709(t *testing.T) {
+	checkParse(t, ll.ParseISO6709, "+40.7128-074.0060/", 40.7128, -74.0060)
+	checkParse(t, ll.ParseISO6709, "+4042.77-07400.36/", 40.+42.77/60., -(74. + .36/60.))
+	checkParse(t, ll.ParseISO6709, "+404246-0740022", nycLat, nycLon)
+
+	checkParseError(t, ll.ParseISO6709, "+40.7128-74.0060/", "-74.0060", 8, ll.ErrInvalidFormat)
+	checkParseError(t, ll.ParseISO6709, "+4062.77-07400.36/", "+4062.77", 0, ll.ErrInvalidFormat)
+	checkParseError(t, ll.ParseISO6709, "40.7128 -74.0060", "40.7128 -74.0060", 0, ll.ErrInvalidFormat)
+
+	_, err := ll.ParseISO6709("+91.0000+000.0000/")
+	isTrue(t, errors.Is(err, ll.ErrLatitudeOutOfRange), "Out of range")
+}
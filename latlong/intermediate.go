@@ -0,0 +1,17 @@
+package latlong
+
+import (
+	sph "stellarsunset/spherical"
+)
+
+// Returns the point half way along the Great Circle from this LatLong to that LatLong
+func (this *LatLong) MidpointTo(that *LatLong) *LatLong {
+	return this.InterpolateTo(that, .5)
+}
+
+// Returns the point the provided fraction of the way along the Great Circle from this LatLong to that LatLong, fractions outside
+// [0, 1] extrapolate beyond the endpoints.
+func (this *LatLong) InterpolateTo(that *LatLong, fraction float64) *LatLong {
+	latitude, longitude := sph.IntermediatePoint(this.latitude, this.longitude, that.latitude, that.longitude, fraction)
+	return &LatLong{latitude, longitude}
+}
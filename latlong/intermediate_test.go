@@ -0,0 +1,24 @@
+package latlong_test
+
+import (
+	ll "stellarsunset/spherical/latlong"
+	"testing"
+)
+
+func TestMidpointTo(t *testing.T) {
+
+	one, two := ll.NewLatLong(0., 0.), ll.NewLatLong(0., 10.)
+
+	mid := one.MidpointTo(two)
+	withinError(t, 0., mid.Latitude(), 1e-9)
+	withinError(t, 5., mid.Longitude(), 1e-9)
+}
+
+func TestInterpolateTo(t *testing.T) {
+
+	one, two := ll.NewLatLong(40.7128, -74.0060), ll.NewLatLong(35.6764, 139.6500)
+
+	point := one.InterpolateTo(two, .75)
+	withinError(t, .75*one.DistanceInNm(two), one.DistanceInNm(point), 1e-6)
+	withinError(t, .25*one.DistanceInNm(two), point.DistanceInNm(two), 1e-6)
+}
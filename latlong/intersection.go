@@ -0,0 +1,37 @@
+package latlong
+
+import (
+	sph "stellarsunset/spherical"
+	crs "stellarsunset/spherical/course"
+)
+
+// Computes the two (antipodal) points at which the Great Circle leaving this LatLong on the provided course crosses the Great
+// Circle leaving that LatLong on that course, the first intersection returned is the one closest to this LatLong.
+//
+// Returns sph.ErrCoincidentGreatCircles if both paths lie along the same Great Circle.
+func (this *LatLong) Intersection(course *crs.Course, that *LatLong, thatCourse *crs.Course) (*LatLong, *LatLong, error) {
+
+	lat, lon, antiLat, antiLon, err := sph.Intersection(
+		this.latitude, this.longitude, course.InDegrees(), that.latitude, that.longitude, thatCourse.InDegrees())
+
+	if err != nil {
+		return nil, nil, err
+	}
+	return &LatLong{lat, lon}, &LatLong{antiLat, antiLon}, nil
+}
+
+// Computes the point at which the Great Circle segment from start1 to end1 crosses the segment from start2 to end2, along with
+// whether that point falls within the extents of both segments.
+//
+// Returns sph.ErrCoincidentGreatCircles if both segments lie along the same Great Circle.
+func SegmentIntersection(start1, end1, start2, end2 *LatLong) (*LatLong, bool, error) {
+
+	lat, lon, within, err := sph.SegmentIntersection(
+		start1.latitude, start1.longitude, end1.latitude, end1.longitude,
+		start2.latitude, start2.longitude, end2.latitude, end2.longitude)
+
+	if err != nil {
+		return nil, false, err
+	}
+	return &LatLong{lat, lon}, within, nil
+}
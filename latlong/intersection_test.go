@@ -0,0 +1,46 @@
+package latlong_test
+
+import (
+	"errors"
+	sph "stellarsunset/spherical"
+	crs "stellarsunset/spherical/course"
+	ll "stellarsunset/spherical/latlong"
+	"testing"
+)
+
+func TestIntersection(t *testing.T) {
+
+	one, two := ll.NewLatLong(0., -10.), ll.NewLatLong(10., 0.)
+
+	near, far, err := one.Intersection(crs.East(), two, crs.South())
+
+	isEqual(t, nil, err)
+	withinError(t, 0., near.Latitude(), 1e-9)
+	withinError(t, 0., near.Longitude(), 1e-9)
+	withinError(t, 0., far.Latitude(), 1e-9)
+	withinError(t, -180., far.Longitude(), 1e-9)
+}
+
+func TestIntersectionCoincident(t *testing.T) {
+
+	one, two := ll.NewLatLong(0., 0.), ll.NewLatLong(0., 10.)
+
+	_, _, err := one.Intersection(crs.East(), two, crs.West())
+	isTrue(t, errors.Is(err, sph.ErrCoincidentGreatCircles), "Coincident")
+}
+
+func TestSegmentIntersection(t *testing.T) {
+
+	a, b := ll.NewLatLong(0., -1.), ll.NewLatLong(0., 1.)
+	c, d := ll.NewLatLong(-1., 0.), ll.NewLatLong(1., 0.)
+
+	crossing, within, err := ll.SegmentIntersection(a, b, c, d)
+
+	isEqual(t, nil, err)
+	isTrue(t, within, "Within")
+	withinError(t, 0., crossing.Latitude(), 1e-9)
+	withinError(t, 0., crossing.Longitude(), 1e-9)
+
+	_, within, _ = ll.SegmentIntersection(a, b, d, ll.NewLatLong(2., 0.))
+	isFalse(t, within, "Outside")
+}
@@ -3,6 +3,7 @@ package latlong
 import (
 	"errors"
 	"fmt"
+	"math"
 	sph "stellarsunset/spherical"
 	crs "stellarsunset/spherical/course"
 	dist "stellarsunset/spherical/distance"
@@ -13,16 +14,27 @@ type LatLong struct {
 	longitude float64
 }
 
+var (
+	ErrLatitudeOutOfRange  = errors.New("Latitude is out of range (-90, 90)")
+	ErrLongitudeOutOfRange = errors.New("Longitude is out of range (-180, 180)")
+)
+
 func checkLatitude(latitude float64) (float64, error) {
+	if math.IsNaN(latitude) {
+		return latitude, fmt.Errorf("%w: latitude", sph.ErrNaNInput)
+	}
 	if latitude <= -90 || 90. <= latitude {
-		return latitude, errors.New(fmt.Sprintf("Latitude is out of range (-90, 90): %f", latitude))
+		return latitude, fmt.Errorf("%w: %f", ErrLatitudeOutOfRange, latitude)
 	}
 	return latitude, nil
 }
 
 func checkLongitude(longitude float64) (float64, error) {
+	if math.IsNaN(longitude) {
+		return longitude, fmt.Errorf("%w: longitude", sph.ErrNaNInput)
+	}
 	if longitude <= -180 || 180. <= longitude {
-		return longitude, errors.New(fmt.Sprintf("Longitude is out of range (-180, 180): %f", longitude))
+		return longitude, fmt.Errorf("%w: %f", ErrLongitudeOutOfRange, longitude)
 	}
 	return longitude, nil
 }
@@ -30,15 +42,43 @@ func checkLongitude(longitude float64) (float64, error) {
 // Creates a new LatLong struct from the provided latitude and longitude values in degrees, panicking with an error code if the
 // provided latitude or longitude fall outside the accepted ranges (-90, 90), (-180, 180).
 func NewLatLong(latitude, longitude float64) *LatLong {
+	ll, err := TryNewLatLong(latitude, longitude)
+	if err != nil {
+		panic(err)
+	}
+	return ll
+}
+
+// Creates a new LatLong struct from the provided latitude and longitude values in degrees, returning an error (matching either
+// ErrLatitudeOutOfRange, ErrLongitudeOutOfRange or sph.ErrNaNInput) if the provided values fall outside the accepted ranges.
+func TryNewLatLong(latitude, longitude float64) (*LatLong, error) {
 
 	_, laterr := checkLatitude(latitude)
 	_, lonerr := checkLongitude(longitude)
 
 	if laterr != nil || lonerr != nil {
-		panic(errors.Join(laterr, lonerr))
+		return nil, errors.Join(laterr, lonerr)
 	}
 
-	return &LatLong{latitude, longitude}
+	return &LatLong{latitude, longitude}, nil
+}
+
+// Creates a new LatLong from the provided latitude and longitude values in degrees without validating them, wrapping the longitude
+// into [-180, 180) and clamping the latitude to [-90, 90].
+//
+// This is intended for the results of computations (e.g. projections) which can legitimately land on a pole or the antimeridian,
+// NewLatLong should be preferred for validating inputs.
+func Normalized(latitude, longitude float64) *LatLong {
+	return &LatLong{math.Max(-90., math.Min(90., latitude)), normalizeLongitude(longitude)}
+}
+
+// Normalize a longitude in degrees to [-180, 180)
+func normalizeLongitude(longitude float64) float64 {
+	z := math.Remainder(longitude, 360.)
+	if z >= 180. {
+		z -= 360.
+	}
+	return z
 }
 
 func (this *LatLong) Latitude() float64 {
@@ -80,6 +120,16 @@ func (this *LatLong) ProjectOut(direction, distance float64) *LatLong {
 	return &LatLong{latitude, longitude}
 }
 
+// Projects outwards from this LatLong along the provided course (in degrees) the provided distance (in nm) returning a new LL, or
+// an error matching sph.ErrNaNInput if either is NaN.
+func (this *LatLong) ProjectOutE(direction, distance float64) (*LatLong, error) {
+	latitude, longitude, err := sph.ProjectOutE(this.latitude, this.longitude, direction, distance)
+	if err != nil {
+		return nil, err
+	}
+	return &LatLong{latitude, longitude}, nil
+}
+
 func (this *LatLong) CrossTrackDistanceTo(start, end *LatLong) *dist.Distance {
 	return dist.OfNauticalMiles(this.CrossTrackDistanceNm(start, end))
 }
@@ -95,3 +145,17 @@ func (this *LatLong) AlongTrackDistanceTo(start, end *LatLong, crossTrack *dist.
 func (this *LatLong) AlongTrackDistanceNm(start, end *LatLong, crossTrackDistanceNm float64) float64 {
 	return sph.AlongTrackDistanceNm(start.latitude, start.longitude, end.latitude, end.longitude, this.latitude, this.longitude, crossTrackDistanceNm)
 }
+
+func (this *LatLong) AlongTrackDistanceToE(start, end *LatLong, crossTrack *dist.Distance) (*dist.Distance, error) {
+	atd, err := this.AlongTrackDistanceNmE(start, end, crossTrack.InNauticalMiles())
+	if err != nil {
+		return nil, err
+	}
+	return dist.OfNauticalMiles(atd), nil
+}
+
+// Computes the along track distance, returning an error matching sph.ErrDegenerateTrack rather than panicking when the provided
+// cross track distance is inconsistent with the track.
+func (this *LatLong) AlongTrackDistanceNmE(start, end *LatLong, crossTrackDistanceNm float64) (float64, error) {
+	return sph.AlongTrackDistanceNmE(start.latitude, start.longitude, end.latitude, end.longitude, this.latitude, this.longitude, crossTrackDistanceNm)
+}
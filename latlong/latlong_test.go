@@ -1,7 +1,9 @@
 package latlong_test
 
 import (
+	"errors"
 	"math"
+	sph "stellarsunset/spherical"
 	dist "stellarsunset/spherical/distance"
 	ll "stellarsunset/spherical/latlong"
 	"testing"
@@ -127,3 +129,67 @@ func TestAlongTrackDistanceTo(t *testing.T) {
 
 	withinError(t, expected.InNauticalMiles(), actual.InNauticalMiles(), .01)
 }
+
+func TestTryNewLatLong(t *testing.T) {
+
+	ll, err := ll.TryNewLatLong(1., -1.)
+
+	isEqual(t, nil, err)
+	isEqual(t, 1., ll.Latitude())
+	isEqual(t, -1., ll.Longitude())
+}
+
+func TestTryNewLatLongOutOfRange(t *testing.T) {
+
+	_, err := ll.TryNewLatLong(91., 0.)
+	isTrue(t, errors.Is(err, ll.ErrLatitudeOutOfRange), "Latitude")
+	isFalse(t, errors.Is(err, ll.ErrLongitudeOutOfRange), "Longitude")
+
+	_, err = ll.TryNewLatLong(0., -180.)
+	isTrue(t, errors.Is(err, ll.ErrLongitudeOutOfRange), "Longitude")
+
+	_, err = ll.TryNewLatLong(-90., 180.)
+	isTrue(t, errors.Is(err, ll.ErrLatitudeOutOfRange), "Both Latitude")
+	isTrue(t, errors.Is(err, ll.ErrLongitudeOutOfRange), "Both Longitude")
+}
+
+func TestTryNewLatLongNaN(t *testing.T) {
+
+	_, err := ll.TryNewLatLong(math.NaN(), 0.)
+	isTrue(t, errors.Is(err, sph.ErrNaNInput), "NaN")
+}
+
+func TestNewLatLongPanics(t *testing.T) {
+
+	defer func() {
+		r := recover()
+		err, ok := r.(error)
+		isTrue(t, ok && errors.Is(err, ll.ErrLatitudeOutOfRange), "NewLatLong(100, 0) should panic with ErrLatitudeOutOfRange")
+	}()
+	ll.NewLatLong(100., 0.)
+}
+
+func TestProjectOutE(t *testing.T) {
+
+	source := ll.NewLatLong(0., 0.)
+
+	actual, err := source.ProjectOutE(45., dist.OfKilometers(157.2).InNauticalMiles())
+	isEqual(t, nil, err)
+	withinError(t, 1., actual.Latitude(), .01)
+	withinError(t, 1., actual.Longitude(), .01)
+
+	_, err = source.ProjectOutE(math.NaN(), 1.)
+	isTrue(t, errors.Is(err, sph.ErrNaNInput), "NaN")
+}
+
+func TestAlongTrackDistanceToE(t *testing.T) {
+
+	start, end, point := ll.NewLatLong(0., 0.), ll.NewLatLong(0., 10.), ll.NewLatLong(1., -.5)
+
+	actual, err := point.AlongTrackDistanceToE(start, end, point.CrossTrackDistanceTo(start, end))
+	isEqual(t, nil, err)
+	withinError(t, -30.00343415285915, actual.InNauticalMiles(), .01)
+
+	_, err = point.AlongTrackDistanceToE(start, end, dist.OfNauticalMiles(3000.))
+	isTrue(t, errors.Is(err, sph.ErrDegenerateTrack), "Degenerate")
+}
@@ -0,0 +1,132 @@
+package latlong
+
+import (
+	sph "stellarsunset/spherical"
+	crs "stellarsunset/spherical/course"
+	dist "stellarsunset/spherical/distance"
+)
+
+// An EarthModel provides the basic geodesic operations on the surface of a body, allowing callers to trade accuracy for speed
+// (e.g. a Sphere vs an ellipsoid) without changing their call sites.
+type EarthModel interface {
+	// The length of the shortest path between the two LatLongs
+	Distance(from, to *LatLong) *dist.Distance
+	// The initial course of the shortest path between the two LatLongs
+	Course(from, to *LatLong) *crs.Course
+	// The LatLong reached by travelling the given distance from the provided LatLong leaving on the given course
+	Project(from *LatLong, course *crs.Course, distance *dist.Distance) *LatLong
+	// The distance of the position from the path through start and end, positive when to the right and negative when left
+	CrossTrack(start, end, position *LatLong) *dist.Distance
+	// The distance from start along the path through start and end to the closest point to the position, negative when the
+	// closest point is behind start
+	AlongTrack(start, end, position *LatLong) *dist.Distance
+}
+
+// A Sphere is an EarthModel backed by the spherical functions in the root package scaled to the configured radius.
+type Sphere struct {
+	radiusNm float64
+}
+
+var (
+	earth         = NewSphere(dist.OfNauticalMiles(sph.EarthRadiusNm))
+	meanEarth     = NewSphere(dist.OfMeters(6371008.8))
+	authalicEarth = NewSphere(dist.OfMeters(6371007.2))
+	moon          = NewSphere(dist.OfKilometers(1737.4))
+	mars          = NewSphere(dist.OfKilometers(3389.5))
+)
+
+func NewSphere(radius *dist.Distance) *Sphere {
+	return &Sphere{radius.InNauticalMiles()}
+}
+
+// The sphere used by all the non-model methods of LatLong with radius EarthRadiusNm
+func Earth() *Sphere {
+	return earth
+}
+
+// A sphere with the IUGG mean radius of the Earth, (2a + b) / 3 for WGS-84
+func MeanEarth() *Sphere {
+	return meanEarth
+}
+
+// A sphere with the same surface area as the WGS-84 ellipsoid
+func AuthalicEarth() *Sphere {
+	return authalicEarth
+}
+
+// A sphere with the mean radius of the Moon
+func Moon() *Sphere {
+	return moon
+}
+
+// A sphere with the mean radius of Mars
+func Mars() *Sphere {
+	return mars
+}
+
+func (this *Sphere) Radius() *dist.Distance {
+	return dist.OfNauticalMiles(this.radiusNm)
+}
+
+func (this *Sphere) Distance(from, to *LatLong) *dist.Distance {
+	return dist.OfNauticalMiles(this.scale() * from.DistanceInNm(to))
+}
+
+func (this *Sphere) Course(from, to *LatLong) *crs.Course {
+	return from.CourseTo(to)
+}
+
+func (this *Sphere) Project(from *LatLong, course *crs.Course, distance *dist.Distance) *LatLong {
+	return from.ProjectOut(course.InDegrees(), distance.InNauticalMiles()/this.scale())
+}
+
+func (this *Sphere) CrossTrack(start, end, position *LatLong) *dist.Distance {
+	return dist.OfNauticalMiles(this.scale() * position.CrossTrackDistanceNm(start, end))
+}
+
+func (this *Sphere) AlongTrack(start, end, position *LatLong) *dist.Distance {
+	ctd := position.CrossTrackDistanceNm(start, end)
+	return dist.OfNauticalMiles(this.scale() * position.AlongTrackDistanceNm(start, end, ctd))
+}
+
+// Computes the along track distance as AlongTrack does, returning an error matching sph.ErrNaNInput or sph.ErrDegenerateTrack
+// rather than panicking when the track is too poorly conditioned to measure along.
+func (this *Sphere) AlongTrackE(start, end, position *LatLong) (*dist.Distance, error) {
+	ctd := position.CrossTrackDistanceNm(start, end)
+	atd, err := position.AlongTrackDistanceNmE(start, end, ctd)
+	if err != nil {
+		return nil, err
+	}
+	return dist.OfNauticalMiles(this.scale() * atd), nil
+}
+
+// Ratio of this sphere's radius to the radius assumed by the functions in the root package
+func (this *Sphere) scale() float64 {
+	return this.radiusNm / sph.EarthRadiusNm
+}
+
+func (this *LatLong) DistanceUsing(model EarthModel, that *LatLong) *dist.Distance {
+	return model.Distance(this, that)
+}
+
+func (this *LatLong) CourseUsing(model EarthModel, that *LatLong) *crs.Course {
+	return model.Course(this, that)
+}
+
+// Projects outwards from this LatLong along the provided course the provided distance on the given model returning a new LL.
+func (this *LatLong) ProjectUsing(model EarthModel, course *crs.Course, distance *dist.Distance) *LatLong {
+	return model.Project(this, course, distance)
+}
+
+func (this *LatLong) CrossTrackDistanceUsing(model EarthModel, start, end *LatLong) *dist.Distance {
+	return model.CrossTrack(start, end, this)
+}
+
+func (this *LatLong) AlongTrackDistanceUsing(model EarthModel, start, end *LatLong) *dist.Distance {
+	return model.AlongTrack(start, end, this)
+}
+
+// Return true if this LatLong is within (<=) the given distance of the provided LatLong on the given model
+func (this *LatLong) IsWithinUsing(model EarthModel, distance *dist.Distance, that *LatLong) bool {
+	return model.Distance(this, that).IsLessThanOrEqualTo(distance)
+}
@@ -0,0 +1,76 @@
+package latlong_test
+
+import (
+	"errors"
+	"math"
+	sph "stellarsunset/spherical"
+	crs "stellarsunset/spherical/course"
+	dist "stellarsunset/spherical/distance"
+	ll "stellarsunset/spherical/latlong"
+	"testing"
+)
+
+func TestEarthMatchesDefaultMethods(t *testing.T) {
+
+	earth := ll.Earth()
+	one, two, three := ll.NewLatLong(0., 0.), ll.NewLatLong(1., 1.), ll.NewLatLong(1., .5)
+
+	withinError(t, one.DistanceTo(two).InNauticalMiles(), one.DistanceUsing(earth, two).InNauticalMiles(), 1e-9)
+	withinError(t, one.CourseTo(two).InDegrees(), one.CourseUsing(earth, two).InDegrees(), 1e-9)
+
+	projected := one.ProjectUsing(earth, crs.OfDegrees(45.), dist.OfNauticalMiles(60.))
+	expected := one.ProjectOut(45., 60.)
+	withinError(t, expected.Latitude(), projected.Latitude(), 1e-9)
+	withinError(t, expected.Longitude(), projected.Longitude(), 1e-9)
+
+	cross := three.CrossTrackDistanceTo(one, two)
+	withinError(t, cross.InNauticalMiles(), three.CrossTrackDistanceUsing(earth, one, two).InNauticalMiles(), 1e-9)
+	withinError(t, three.AlongTrackDistanceTo(one, two, cross).InNauticalMiles(), three.AlongTrackDistanceUsing(earth, one, two).InNauticalMiles(), 1e-9)
+}
+
+func TestSphereAlongTrackE(t *testing.T) {
+
+	one, two, three := ll.NewLatLong(0., 0.), ll.NewLatLong(1., 1.), ll.NewLatLong(1., .5)
+
+	along, err := ll.MeanEarth().AlongTrackE(one, two, three)
+	isEqual(t, nil, err)
+	withinError(t, ll.MeanEarth().AlongTrack(one, two, three).InNauticalMiles(), along.InNauticalMiles(), 1e-9)
+
+	_, err = ll.Earth().AlongTrackE(one, two, ll.Normalized(math.NaN(), 0.))
+	isTrue(t, errors.Is(err, sph.ErrNaNInput), "NaN position")
+}
+
+func TestSphereRadius(t *testing.T) {
+
+	withinError(t, 6371008.8, ll.MeanEarth().Radius().InMeters(), 1e-6)
+	withinError(t, 6371007.2, ll.AuthalicEarth().Radius().InMeters(), 1e-6)
+	withinError(t, 1737.4, ll.Moon().Radius().InKilometers(), 1e-9)
+	withinError(t, 3389.5, ll.Mars().Radius().InKilometers(), 1e-9)
+}
+
+func TestSphereDistanceScalesWithRadius(t *testing.T) {
+
+	one, two := ll.NewLatLong(0., 0.), ll.NewLatLong(0., 90.)
+
+	// A quarter of the way around the equator
+	withinError(t, 1737.4*3.141592653589793/2., one.DistanceUsing(ll.Moon(), two).InKilometers(), 1e-6)
+	withinError(t, 3389.5*3.141592653589793/2., one.DistanceUsing(ll.Mars(), two).InKilometers(), 1e-6)
+}
+
+func TestSphereProjectRoundTrip(t *testing.T) {
+
+	moon, start := ll.Moon(), ll.NewLatLong(10., 20.)
+
+	end := start.ProjectUsing(moon, crs.OfDegrees(30.), dist.OfKilometers(100.))
+
+	withinError(t, 100., start.DistanceUsing(moon, end).InKilometers(), 1e-6)
+	withinError(t, 30., start.CourseUsing(moon, end).InDegrees(), 1e-6)
+}
+
+func TestIsWithinUsing(t *testing.T) {
+
+	one, two := ll.NewLatLong(0., 0.), ll.NewLatLong(1., 1.)
+
+	isTrue(t, one.IsWithinUsing(ll.Moon(), dist.OfKilometers(50), two), "50km on the Moon")
+	isFalse(t, one.IsWithinUsing(ll.Earth(), dist.OfKilometers(50), two), "50km on the Earth")
+}
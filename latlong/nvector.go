@@ -0,0 +1,62 @@
+package latlong
+
+import (
+	"math"
+	crs "stellarsunset/spherical/course"
+	dist "stellarsunset/spherical/distance"
+	vec "stellarsunset/spherical/vector"
+)
+
+// Returns the n-vector of this LatLong, the unit vector from the center of the Earth normal to its surface at this location.
+//
+// The x-axis points to (0, 0), the y-axis to (0, 90) and the z-axis to the north pole.
+func (this *LatLong) ToNVector() *vec.Vec3 {
+	sinLat, cosLat := math.Sincos(toRadians(this.latitude))
+	sinLon, cosLon := math.Sincos(toRadians(this.longitude))
+	return vec.Of(cosLat*cosLon, cosLat*sinLon, sinLat)
+}
+
+// Returns the LatLong whose n-vector points in the same direction as the provided (not necessarily unit) vector
+func FromNVector(v *vec.Vec3) *LatLong {
+	latitude := toDegrees(math.Atan2(v.Z(), math.Hypot(v.X(), v.Y())))
+	longitude := toDegrees(math.Atan2(v.Y(), v.X()))
+	return &LatLong{latitude, normalizeLongitude(longitude)}
+}
+
+// Returns the unit normal to the Great Circle leaving this LatLong on the provided course, positions to the left of the course
+// have a positive dot product with it.
+func (this *LatLong) GreatCircleNormal(course *crs.Course) *vec.Vec3 {
+
+	sinLat, cosLat := math.Sincos(toRadians(this.latitude))
+	sinLon, cosLon := math.Sincos(toRadians(this.longitude))
+
+	north := vec.Of(-sinLat*cosLon, -sinLat*sinLon, cosLat)
+	east := vec.Of(-sinLon, cosLon, 0.)
+
+	direction := north.Times(course.Cos()).Plus(east.Times(course.Sin()))
+	return this.ToNVector().Cross(direction)
+}
+
+// Returns the unit normal to the Great Circle passing from this LatLong through that LatLong, positions to the left of the path
+// have a positive dot product with it.
+func (this *LatLong) GreatCircleNormalTo(that *LatLong) *vec.Vec3 {
+	return this.ToNVector().Cross(that.ToNVector()).Normalize()
+}
+
+// Returns the Earth-Centered Earth-Fixed position (in meters) of this LatLong at the provided altitude on the provided Sphere.
+func (this *Sphere) ToECEF(ll *LatLong, altitude *dist.Distance) *vec.Vec3 {
+	return ll.ToNVector().Times(this.Radius().InMeters() + altitude.InMeters())
+}
+
+// Returns the LatLong and altitude above the provided Sphere of an Earth-Centered Earth-Fixed position in meters.
+func (this *Sphere) FromECEF(ecef *vec.Vec3) (*LatLong, *dist.Distance) {
+	return FromNVector(ecef), dist.OfMeters(ecef.Norm() - this.Radius().InMeters())
+}
+
+func toRadians(degrees float64) float64 {
+	return degrees * math.Pi / 180.
+}
+
+func toDegrees(radians float64) float64 {
+	return radians * 180. / math.Pi
+}
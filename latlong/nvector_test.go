@@ -0,0 +1,83 @@
+package latlong_test
+
+import (
+	"math"
+	crs "stellarsunset/spherical/course"
+	dist "stellarsunset/spherical/distance"
+	ll "stellarsunset/spherical/latlong"
+	vec "stellarsunset/spherical/vector"
+	"testing"
+)
+
+func TestToNVector(t *testing.T) {
+
+	v := ll.NewLatLong(0., 0.).ToNVector()
+	withinError(t, 1., v.X(), 1e-15)
+	withinError(t, 0., v.Y(), 1e-15)
+	withinError(t, 0., v.Z(), 1e-15)
+
+	v = ll.NewLatLong(0., 90.).ToNVector()
+	withinError(t, 0., v.X(), 1e-15)
+	withinError(t, 1., v.Y(), 1e-15)
+
+	v = ll.NewLatLong(45., 0.).ToNVector()
+	withinError(t, math.Sqrt(2.)/2., v.X(), 1e-15)
+	withinError(t, math.Sqrt(2.)/2., v.Z(), 1e-15)
+}
+
+func TestFromNVector(t *testing.T) {
+
+	source := ll.NewLatLong(-33.8688, 151.2093)
+	actual := ll.FromNVector(source.ToNVector().Times(7.))
+
+	withinError(t, source.Latitude(), actual.Latitude(), 1e-12)
+	withinError(t, source.Longitude(), actual.Longitude(), 1e-12)
+
+	pole := ll.FromNVector(vec.Of(0., 0., 1.))
+	withinError(t, 90., pole.Latitude(), 0.)
+
+	antimeridian := ll.FromNVector(vec.Of(-1., 0., 0.))
+	withinError(t, -180., antimeridian.Longitude(), 0.)
+}
+
+func TestGreatCircleNormal(t *testing.T) {
+
+	// The equator travelling east has the north pole as its normal
+	normal := ll.NewLatLong(0., 10.).GreatCircleNormal(crs.East())
+	withinError(t, 1., normal.Z(), 1e-15)
+
+	// Positions to the left of a track have a positive dot product with its normal, consistent with CrossTrackDistanceTo
+	start, end, left := ll.NewLatLong(0., 0.), ll.NewLatLong(0., 10.), ll.NewLatLong(1., 5.)
+	isTrue(t, left.ToNVector().Dot(start.GreatCircleNormalTo(end)) > 0., "Left")
+	isTrue(t, left.CrossTrackDistanceTo(start, end).IsNegative(), "Left")
+
+	// Both forms agree
+	n1, n2 := start.GreatCircleNormal(start.CourseTo(end)), start.GreatCircleNormalTo(end)
+	withinError(t, 0., n1.Minus(n2).Norm(), 1e-12)
+}
+
+func TestSphereECEF(t *testing.T) {
+
+	earth := ll.MeanEarth()
+
+	ecef := earth.ToECEF(ll.NewLatLong(0., 90.), dist.OfMeters(1000.))
+	withinError(t, 0., ecef.X(), 1e-6)
+	withinError(t, 6372008.8, ecef.Y(), 1e-6)
+	withinError(t, 0., ecef.Z(), 1e-6)
+
+	position, altitude := earth.FromECEF(ecef)
+	withinError(t, 0., position.Latitude(), 1e-12)
+	withinError(t, 90., position.Longitude(), 1e-12)
+	withinError(t, 1000., altitude.InMeters(), 1e-6)
+}
+
+func TestNormalized(t *testing.T) {
+
+	one := ll.Normalized(90., 180.)
+	withinError(t, 90., one.Latitude(), 0.)
+	withinError(t, -180., one.Longitude(), 0.)
+
+	two := ll.Normalized(95., 370.)
+	withinError(t, 90., two.Latitude(), 0.)
+	withinError(t, 10., two.Longitude(), 1e-12)
+}
@@ -0,0 +1,374 @@
+package latlong
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Returned (wrapped in a ParseError) when a coordinate string doesn't match the expected format
+var ErrInvalidFormat = errors.New("Invalid coordinate format")
+
+// A ParseError records the portion of the input a coordinate string couldn't be parsed at, along with the reason. The reason
+// matches ErrInvalidFormat for malformed input or ErrLatitudeOutOfRange/ErrLongitudeOutOfRange for out of range values.
+type ParseError struct {
+	Input string
+	// The offending portion of the input and its byte offset within it
+	Token  string
+	Offset int
+	Err    error
+}
+
+func (this *ParseError) Error() string {
+	return fmt.Sprintf("Unable to parse %q, %v at offset %d: %q", this.Input, this.Err, this.Offset, this.Token)
+}
+
+func (this *ParseError) Unwrap() error {
+	return this.Err
+}
+
+var compact = regexp.MustCompile(`(?i)^\s*(\d+)(?:\.\d+)?[NS]\s*\d+(?:\.\d+)?[EW]\s*$`)
+
+// Parses a LatLong from a string in any of the formats accepted by the other Parse functions, detecting the format from the
+// string itself, e.g.
+//
+//	40.7128, -74.0060            (ParseDecimal)
+//	40°42'46"N 74°00'22"W        (ParseDMS)
+//	N40 42.77 W074 00.36         (ParseDDM)
+//	4042.8N 07400.4W             (ParseCompactDDM)
+//	404246N0740022W              (ParseICAO)
+//	+40.7128-074.0060/           (ParseISO6709)
+//
+// Returns a *ParseError identifying the offending portion of the input if it can't be parsed.
+func Parse(input string) (*LatLong, error) {
+	if strings.HasSuffix(strings.TrimSpace(input), "/") {
+		return ParseISO6709(input)
+	}
+	if m := compact.FindStringSubmatch(input); m != nil {
+		switch len(m[1]) {
+		case 4:
+			return ParseCompactDDM(input)
+		case 6:
+			return ParseICAO(input)
+		}
+	}
+	return parseDelimited(input, 0)
+}
+
+// Parses signed decimal degrees separated by a comma and/or whitespace, e.g. "40.7128, -74.0060". Hemisphere letters may be
+// used in place of signs, e.g. "40.7128N 74.0060W", and degree symbols are optional.
+func ParseDecimal(input string) (*LatLong, error) {
+	return parseDelimited(input, 1)
+}
+
+// Parses degrees, minutes and (decimal) seconds with hemisphere letters before or after each coordinate, e.g.
+// "40°42'46.1"N 74°00'22"W". The unit symbols are optional, "N40 42 46 W74 0 22" is equivalent.
+func ParseDMS(input string) (*LatLong, error) {
+	return parseDelimited(input, 3)
+}
+
+// Parses degrees and decimal minutes with hemisphere letters before or after each coordinate, e.g. "N40 42.77 W074 00.36" or
+// "40°42.77'N 74°00.36'W".
+func ParseDDM(input string) (*LatLong, error) {
+	return parseDelimited(input, 2)
+}
+
+// Parses the fixed width degrees and decimal minutes format common in aviation, DDMM.mH DDDMM.mH, e.g. "4042.8N 07400.4W".
+// The space and the decimal minutes are optional.
+func ParseCompactDDM(input string) (*LatLong, error) {
+	return parseCompact(input, 2)
+}
+
+// Parses the fixed width degrees, minutes and seconds format used in ICAO flight plans, DDMMSSHDDDMMSSH, e.g.
+// "404246N0740022W". Decimal seconds and a space between the coordinates are accepted.
+func ParseICAO(input string) (*LatLong, error) {
+	return parseCompact(input, 3)
+}
+
+type tokenKind int
+
+const (
+	numberToken tokenKind = iota
+	unitToken
+	hemisphereToken
+	separatorToken
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	offset int
+	// The position of a unit symbol, 0 for degrees, 1 for minutes and 2 for seconds
+	unit int
+}
+
+func (this token) end() int {
+	return this.offset + len(this.text)
+}
+
+var units = map[rune]int{'°': 0, 'º': 0, '\'': 1, '′': 1, '’': 1, '"': 2, '″': 2, '”': 2}
+
+func tokenize(input string) ([]token, error) {
+
+	var tokens []token
+	for i := 0; i < len(input); {
+		r, size := utf8.DecodeRuneInString(input[i:])
+
+		switch unit, isUnit := units[r]; {
+		case unicode.IsSpace(r):
+		case r == ',':
+			tokens = append(tokens, token{kind: separatorToken, text: input[i : i+size], offset: i})
+		case isUnit:
+			// Two single quotes are often used in place of a double quote
+			if unit == 1 && strings.HasPrefix(input[i+size:], "'") {
+				unit, size = 2, size+1
+			}
+			tokens = append(tokens, token{kind: unitToken, text: input[i : i+size], offset: i, unit: unit})
+		case strings.ContainsRune("NSEWnsew", r):
+			tokens = append(tokens, token{kind: hemisphereToken, text: input[i : i+size], offset: i})
+		case strings.ContainsRune("+-.0123456789", r):
+			end := i + 1
+			for end < len(input) && strings.ContainsRune(".0123456789", rune(input[end])) {
+				end++
+			}
+			tokens = append(tokens, token{kind: numberToken, text: input[i:end], offset: i})
+			size = end - i
+		default:
+			return nil, &ParseError{input, input[i : i+size], i, fmt.Errorf("%w: unexpected character", ErrInvalidFormat)}
+		}
+		i += size
+	}
+	return tokens, nil
+}
+
+// Parses a pair of coordinates each made up of the given number of (degree, minute, second) components separated by whitespace
+// or unit symbols, or any number of them if zero.
+func parseDelimited(input string, components int) (*LatLong, error) {
+
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without any hemisphere letters the only unambiguous format is signed decimals
+	if components == 0 {
+		components = 1
+		for _, t := range tokens {
+			if t.kind == hemisphereToken {
+				components = 0
+			}
+		}
+	}
+
+	p := &parser{input: input, tokens: tokens}
+
+	latitude, err := p.coordinate("NS", components)
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind == separatorToken {
+		p.next()
+	}
+	longitude, err := p.coordinate("EW", components)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(tokens) {
+		return nil, p.errorAt(p.peek(), "unexpected trailing input")
+	}
+	return p.build(latitude, longitude)
+}
+
+type parser struct {
+	input  string
+	tokens []token
+	pos    int
+}
+
+// A parsed coordinate value along with the tokens it was parsed from
+type coordinate struct {
+	degrees     float64
+	first, last token
+}
+
+func (this *parser) peek() token {
+	if this.pos < len(this.tokens) {
+		return this.tokens[this.pos]
+	}
+	return token{kind: -1, offset: len(this.input)}
+}
+
+func (this *parser) next() token {
+	t := this.peek()
+	this.pos++
+	return t
+}
+
+func (this *parser) errorAt(t token, reason string) *ParseError {
+	return &ParseError{this.input, t.text, t.offset, fmt.Errorf("%w: %s", ErrInvalidFormat, reason)}
+}
+
+// Parses a single coordinate, i.e. a signed decimal value or one or more components with a hemisphere letter from the given
+// set either before or after them.
+func (this *parser) coordinate(hemispheres string, components int) (coordinate, error) {
+
+	expectedHemisphere := "expected hemisphere " + strings.Join(strings.Split(hemispheres, ""), " or ")
+	maxComponents := components
+	if components == 0 {
+		maxComponents = 3
+	}
+
+	first := this.peek()
+
+	var hemisphere *token
+	if first.kind == hemisphereToken {
+		t := this.next()
+		hemisphere = &t
+	}
+
+	var values []float64
+	var signed bool
+	for len(values) < maxComponents && this.peek().kind == numberToken {
+
+		// A signed value starts the next coordinate
+		t := this.peek()
+		if strings.ContainsAny(t.text, "+-") {
+			if len(values) > 0 {
+				break
+			}
+			signed = true
+		}
+		this.next()
+
+		value, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return coordinate{}, this.errorAt(t, "invalid number")
+		}
+		if len(values) > 0 && value >= 60. {
+			return coordinate{}, this.errorAt(t, "minutes and seconds must be less than 60")
+		}
+		if u := this.peek(); u.kind == unitToken {
+			if u.unit != len(values) {
+				return coordinate{}, this.errorAt(u, "unexpected unit")
+			}
+			this.next()
+		}
+		values = append(values, value)
+
+		// Only the last component may have a fractional part
+		if strings.Contains(t.text, ".") {
+			break
+		}
+	}
+
+	if len(values) == 0 {
+		return coordinate{}, this.errorAt(this.peek(), "expected a number")
+	}
+	if components > 0 && len(values) != components {
+		return coordinate{}, this.errorAt(first, fmt.Sprintf("expected %d components, got %d", components, len(values)))
+	}
+
+	if hemisphere == nil && this.peek().kind == hemisphereToken {
+		t := this.next()
+		hemisphere = &t
+	}
+	switch {
+	case hemisphere == nil && len(values) > 1:
+		return coordinate{}, this.errorAt(this.peek(), expectedHemisphere)
+	case hemisphere != nil && !strings.Contains(hemispheres, strings.ToUpper(hemisphere.text)):
+		return coordinate{}, this.errorAt(*hemisphere, expectedHemisphere)
+	case hemisphere != nil && signed:
+		return coordinate{}, this.errorAt(first, "unexpected sign with hemisphere")
+	}
+
+	degrees := 0.
+	for i, v := range values {
+		degrees += math.Abs(v) / math.Pow(60., float64(i))
+	}
+	if values[0] < 0. || (hemisphere != nil && strings.ContainsAny(hemisphere.text, "SWsw")) {
+		degrees = -degrees
+	}
+	return coordinate{degrees, first, this.tokens[this.pos-1]}, nil
+}
+
+// Validates the parsed latitude and longitude, pointing errors at the offending coordinate
+func (this *parser) build(latitude, longitude coordinate) (*LatLong, error) {
+	if _, err := checkLatitude(latitude.degrees); err != nil {
+		return nil, this.spanError(latitude, err)
+	}
+	if _, err := checkLongitude(longitude.degrees); err != nil {
+		return nil, this.spanError(longitude, err)
+	}
+	return &LatLong{latitude.degrees, longitude.degrees}, nil
+}
+
+func (this *parser) spanError(c coordinate, err error) *ParseError {
+	return &ParseError{this.input, this.input[c.first.offset:c.last.end()], c.first.offset, err}
+}
+
+// Parses a fixed width coordinate pair where latitudes are made up of the given number of two digit components, the last of which
+// may have a fractional part, and longitudes have three digit degrees.
+func parseCompact(input string, components int) (*LatLong, error) {
+
+	split := strings.IndexAny(input, "NSns") + 1
+	if split == 0 {
+		return nil, &ParseError{input, input, 0, fmt.Errorf("%w: expected hemisphere N or S", ErrInvalidFormat)}
+	}
+
+	latitude, err := parseCompactCoordinate(input, 0, split, 2, components, "NS")
+	if err != nil {
+		return nil, err
+	}
+	longitude, err := parseCompactCoordinate(input, split, len(input), 3, components, "EW")
+	if err != nil {
+		return nil, err
+	}
+	return &LatLong{latitude, longitude}, nil
+}
+
+func parseCompactCoordinate(input string, start, end, degreeDigits, components int, hemispheres string) (float64, error) {
+
+	token := strings.TrimSpace(input[start:end])
+	offset := start + strings.Index(input[start:end], token)
+
+	fail := func(err error) (float64, error) {
+		return 0., &ParseError{input, token, offset, err}
+	}
+
+	digits := degreeDigits + 2*(components-1)
+	pattern := fmt.Sprintf(`^\d{%d}(\.\d+)?[%s%s]$`, digits, hemispheres, strings.ToLower(hemispheres))
+	if matched, _ := regexp.MatchString(pattern, token); !matched {
+		return fail(fmt.Errorf("%w: expected %d digits followed by hemisphere %s", ErrInvalidFormat, digits, strings.Join(strings.Split(hemispheres, ""), " or ")))
+	}
+
+	degrees, _ := strconv.ParseFloat(token[:degreeDigits], 64)
+	for i := 1; i < components; i++ {
+		from, to := degreeDigits+2*(i-1), degreeDigits+2*i
+		if i == components-1 {
+			to = len(token) - 1
+		}
+		value, _ := strconv.ParseFloat(token[from:to], 64)
+		if value >= 60. {
+			return fail(fmt.Errorf("%w: minutes and seconds must be less than 60", ErrInvalidFormat))
+		}
+		degrees += value / math.Pow(60., float64(i))
+	}
+
+	if strings.ContainsAny(token[len(token)-1:], "SWsw") {
+		degrees = -degrees
+	}
+
+	check := checkLatitude
+	if hemispheres == "EW" {
+		check = checkLongitude
+	}
+	if _, err := check(degrees); err != nil {
+		return fail(err)
+	}
+	return degrees, nil
+}
@@ -0,0 +1,101 @@
+package latlong_test
+
+import (
+	"errors"
+	ll "stellarsunset/spherical/latlong"
+	"testing"
+)
+
+func checkParse(t *testing.T, parse func(string) (*ll.LatLong, error), input string, lat, lon float64) {
+	p, err := parse(input)
+	if err != nil {
+		t.Errorf("%q: unexpected error %v", input, err)
+		return
+	}
+	withinError(t, lat, p.Latitude(), 1e-9)
+	withinError(t, lon, p.Longitude(), 1e-9)
+}
+
+func checkParseError(t *testing.T, parse func(string) (*ll.LatLong, error), input string, token string, offset int, target error) {
+	_, err := parse(input)
+
+	var parseErr *ll.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Errorf("%q: expected a ParseError, got %v", input, err)
+		return
+	}
+	isEqual(t, token, parseErr.Token)
+	isEqual(t, offset, parseErr.Offset)
+	isTrue(t, errors.Is(err, target), err.Error())
+}
+
+const (
+	nycLat = 40. + 42./60. + 46./3600.
+	nycLon = -(74. + 0./60. + 22./3600.)
+)
+
+func TestParse(t *testing.T) {
+	checkParse(t, ll.Parse, "40.7128, -74.0060", 40.7128, -74.0060)
+	checkParse(t, ll.Parse, "40.7128 -74.0060", 40.7128, -74.0060)
+	checkParse(t, ll.Parse, "-33.8688,151.2093", -33.8688, 151.2093)
+	checkParse(t, ll.Parse, "40.7128N 74.0060W", 40.7128, -74.0060)
+	checkParse(t, ll.Parse, `40°42'46"N 74°00'22"W`, nycLat, nycLon)
+	checkParse(t, ll.Parse, "40°42′46″N, 74°0′22″W", nycLat, nycLon)
+	checkParse(t, ll.Parse, "N40 42.77 W074 00.36", 40.+42.77/60., -(74. + .36/60.))
+	checkParse(t, ll.Parse, "4042.8N 07400.4W", 40.+42.8/60., -(74. + .4/60.))
+	checkParse(t, ll.Parse, "4042N07400W", 40.+42./60., -74.)
+	checkParse(t, ll.Parse, "404246N0740022W", nycLat, nycLon)
+	checkParse(t, ll.Parse, "335208S1511234E", -(33. + 52./60. + 8./3600.), 151.+12./60.+34./3600.)
+}
+
+func TestParseDecimal(t *testing.T) {
+	checkParse(t, ll.ParseDecimal, "+40.7128°, -74.0060°", 40.7128, -74.0060)
+	checkParse(t, ll.ParseDecimal, "S33.8688 E151.2093", -33.8688, 151.2093)
+
+	checkParseError(t, ll.ParseDecimal, "40.7128, -74.0060, 12", ",", 17, ll.ErrInvalidFormat)
+	checkParseError(t, ll.ParseDecimal, "40.7128N -74.0060W", "-74.0060", 9, ll.ErrInvalidFormat)
+	checkParseError(t, ll.ParseDecimal, "40 42 N 74 0 W", "N", 6, ll.ErrInvalidFormat)
+	checkParseError(t, ll.ParseDecimal, "95.0, 10.0", "95.0", 0, ll.ErrLatitudeOutOfRange)
+	checkParseError(t, ll.ParseDecimal, "45.0, 190.0", "190.0", 6, ll.ErrLongitudeOutOfRange)
+}
+
+func TestParseDMS(t *testing.T) {
+	checkParse(t, ll.ParseDMS, `40°42'46"N 74°00'22"W`, nycLat, nycLon)
+	checkParse(t, ll.ParseDMS, `40°42'46''N 74°00'22''W`, nycLat, nycLon)
+	checkParse(t, ll.ParseDMS, "N40 42 46 W74 0 22", nycLat, nycLon)
+	checkParse(t, ll.ParseDMS, `40 42 46.5 s 74 0 22.25 e`, -(40. + 42./60. + 46.5/3600.), 74.+22.25/3600.)
+
+	checkParseError(t, ll.ParseDMS, `40°42.5'N 74°00'22"W`, "40", 0, ll.ErrInvalidFormat)
+	checkParseError(t, ll.ParseDMS, `40°42'46"N 74°00'22"N`, "N", 22, ll.ErrInvalidFormat)
+	checkParseError(t, ll.ParseDMS, `40°42'66"N 74°00'22"W`, "66", 7, ll.ErrInvalidFormat)
+	checkParseError(t, ll.ParseDMS, `40'42°46"N 74°00'22"W`, "'", 2, ll.ErrInvalidFormat)
+	checkParseError(t, ll.ParseDMS, `40°42'46"N 74°00'22"W!`, "!", 23, ll.ErrInvalidFormat)
+	checkParseError(t, ll.ParseDMS, `40°42'46" 74°00'22"W`, "74", 11, ll.ErrInvalidFormat)
+	checkParseError(t, ll.ParseDMS, `90°00'01"N 74°00'22"W`, `90°00'01"N`, 0, ll.ErrLatitudeOutOfRange)
+}
+
+func TestParseDDM(t *testing.T) {
+	checkParse(t, ll.ParseDDM, "N40 42.77 W074 00.36", 40.+42.77/60., -(74. + .36/60.))
+	checkParse(t, ll.ParseDDM, "40°42.77'N 74°00.36'W", 40.+42.77/60., -(74. + .36/60.))
+
+	checkParseError(t, ll.ParseDDM, "N40 42 46 W074 00.36", "46", 7, ll.ErrInvalidFormat)
+	checkParseError(t, ll.ParseDDM, "E40 42.77 W074 00.36", "E", 0, ll.ErrInvalidFormat)
+}
+
+func TestParseCompactDDM(t *testing.T) {
+	checkParse(t, ll.ParseCompactDDM, "4042.8N 07400.4W", 40.+42.8/60., -(74. + .4/60.))
+	checkParse(t, ll.ParseCompactDDM, "4042N07400W", 40.+42./60., -74.)
+
+	checkParseError(t, ll.ParseCompactDDM, "4042.8N 7400.4W", "7400.4W", 8, ll.ErrInvalidFormat)
+	checkParseError(t, ll.ParseCompactDDM, "4062.8N 07400.4W", "4062.8N", 0, ll.ErrInvalidFormat)
+	checkParseError(t, ll.ParseCompactDDM, "07400.4W", "07400.4W", 0, ll.ErrInvalidFormat)
+	checkParseError(t, ll.ParseCompactDDM, "4042.8N 18100.0E", "18100.0E", 8, ll.ErrLongitudeOutOfRange)
+}
+
+func TestParseICAO(t *testing.T) {
+	checkParse(t, ll.ParseICAO, "404246N0740022W", nycLat, nycLon)
+	checkParse(t, ll.ParseICAO, "404246.5N 0740022.25W", 40.+42./60.+46.5/3600., -(74. + 22.25/3600.))
+
+	checkParseError(t, ll.ParseICAO, "404246N740022W", "740022W", 7, ll.ErrInvalidFormat)
+	checkParseError(t, ll.ParseICAO, "404246X0740022W", "404246X0740022W", 0, ll.ErrInvalidFormat)
+}
@@ -0,0 +1,45 @@
+package latlong
+
+import (
+	sph "stellarsunset/spherical"
+	crs "stellarsunset/spherical/course"
+	dist "stellarsunset/spherical/distance"
+)
+
+func (this *LatLong) RhumbDistanceTo(that *LatLong) *dist.Distance {
+	return dist.OfNauticalMiles(this.RhumbDistanceInNm(that))
+}
+
+func (this *LatLong) RhumbDistanceInNm(that *LatLong) float64 {
+	return sph.RhumbDistanceInNm(this.latitude, this.longitude, that.latitude, that.longitude)
+}
+
+func (this *LatLong) RhumbCourseTo(that *LatLong) *crs.Course {
+	return crs.OfDegrees(this.RhumbCourseInDegrees(that))
+}
+
+func (this *LatLong) RhumbCourseInDegrees(that *LatLong) float64 {
+	return sph.RhumbCourseInDegrees(this.latitude, this.longitude, that.latitude, that.longitude)
+}
+
+// Projects outwards from this LatLong holding the provided course (in degrees) for the provided distance (in nm) returning a new LL.
+// Paths other than due north or south stop at a pole rather than passing it, see sph.RhumbProjectOut.
+func (this *LatLong) RhumbProjectOut(direction, distance float64) *LatLong {
+	latitude, longitude := sph.RhumbProjectOut(this.latitude, this.longitude, direction, distance)
+	return &LatLong{latitude, longitude}
+}
+
+// Projects outwards from this LatLong holding the provided course (in degrees) for the provided distance (in nm) returning a new LL,
+// or an error matching sph.ErrNaNInput if either is NaN or sph.ErrPastPole if the path would need to pass a pole.
+func (this *LatLong) RhumbProjectOutE(direction, distance float64) (*LatLong, error) {
+	latitude, longitude, err := sph.RhumbProjectOutE(this.latitude, this.longitude, direction, distance)
+	if err != nil {
+		return nil, err
+	}
+	return &LatLong{latitude, longitude}, nil
+}
+
+// Projects outwards from this LatLong holding the provided course for the provided distance returning a new LL.
+func (this *LatLong) RhumbProject(course *crs.Course, distance *dist.Distance) *LatLong {
+	return this.RhumbProjectOut(course.InDegrees(), distance.InNauticalMiles())
+}
@@ -0,0 +1,51 @@
+package latlong_test
+
+import (
+	"errors"
+	"math"
+	sph "stellarsunset/spherical"
+	crs "stellarsunset/spherical/course"
+	dist "stellarsunset/spherical/distance"
+	ll "stellarsunset/spherical/latlong"
+	"testing"
+)
+
+func TestRhumbDistanceTo(t *testing.T) {
+
+	dover, calais := ll.NewLatLong(51.127, 1.338), ll.NewLatLong(50.964, 1.853)
+
+	withinError(t, 40.31, dover.RhumbDistanceTo(calais).InKilometers(), .05)
+}
+
+func TestRhumbCourseTo(t *testing.T) {
+
+	dover, calais := ll.NewLatLong(51.127, 1.338), ll.NewLatLong(50.964, 1.853)
+
+	withinError(t, 116.7, dover.RhumbCourseTo(calais).InDegrees(), .1)
+}
+
+func TestRhumbProject(t *testing.T) {
+
+	dover, calais := ll.NewLatLong(51.127, 1.338), ll.NewLatLong(50.964, 1.853)
+
+	actual := dover.RhumbProject(dover.RhumbCourseTo(calais), dover.RhumbDistanceTo(calais))
+
+	withinError(t, calais.Latitude(), actual.Latitude(), 1e-9)
+	withinError(t, calais.Longitude(), actual.Longitude(), 1e-9)
+}
+
+func TestRhumbProjectOutEast(t *testing.T) {
+
+	source := ll.NewLatLong(0., 0.)
+
+	actual := source.RhumbProjectOut(crs.East().InDegrees(), dist.OfKilometers(111.2).InNauticalMiles())
+
+	withinError(t, 0., actual.Latitude(), 1e-9)
+	withinError(t, 1., actual.Longitude(), .01)
+}
+
+func TestRhumbProjectOutE(t *testing.T) {
+
+	_, err := ll.NewLatLong(0., 0.).RhumbProjectOutE(math.NaN(), 1.)
+	isTrue(t, errors.Is(err, sph.ErrNaNInput), "NaN")
+}
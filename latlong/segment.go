@@ -0,0 +1,52 @@
+package latlong
+
+import (
+	sph "stellarsunset/spherical"
+	dist "stellarsunset/spherical/distance"
+)
+
+// Returns the point on the Great Circle segment between start and end that is closest to this LatLong, along with the distance
+// to it and whether it is the start, the end or somewhere in between.
+func (this *LatLong) ClosestPointOnSegment(start, end *LatLong) (*LatLong, *dist.Distance, sph.SegmentLocation) {
+
+	latitude, longitude, distanceNm, location := sph.ClosestPointOnSegment(
+		start.latitude, start.longitude, end.latitude, end.longitude, this.latitude, this.longitude)
+
+	return &LatLong{latitude, longitude}, dist.OfNauticalMiles(distanceNm), location
+}
+
+// Returns the closest point on the Great Circle segment between start and end, returning an error matching sph.ErrDegenerateTrack
+// rather than panicking if the segment is too poorly conditioned to measure along.
+func (this *LatLong) ClosestPointOnSegmentE(start, end *LatLong) (*LatLong, *dist.Distance, sph.SegmentLocation, error) {
+
+	latitude, longitude, distanceNm, location, err := sph.ClosestPointOnSegmentE(
+		start.latitude, start.longitude, end.latitude, end.longitude, this.latitude, this.longitude)
+
+	if err != nil {
+		return nil, nil, location, err
+	}
+	return &LatLong{latitude, longitude}, dist.OfNauticalMiles(distanceNm), location, nil
+}
+
+// Returns the distance from this LatLong to the closest point on the Great Circle segment between start and end
+func (this *LatLong) DistanceToSegment(start, end *LatLong) *dist.Distance {
+	return dist.OfNauticalMiles(this.DistanceToSegmentNm(start, end))
+}
+
+func (this *LatLong) DistanceToSegmentNm(start, end *LatLong) float64 {
+	return sph.DistanceToSegmentNm(start.latitude, start.longitude, end.latitude, end.longitude, this.latitude, this.longitude)
+}
+
+// Returns the distance from this LatLong to the closest point on the Great Circle segment between start and end, or an error
+// matching sph.ErrDegenerateTrack rather than panicking if the segment's Great Circle is undefined.
+func (this *LatLong) DistanceToSegmentE(start, end *LatLong) (*dist.Distance, error) {
+	distanceNm, err := this.DistanceToSegmentNmE(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return dist.OfNauticalMiles(distanceNm), nil
+}
+
+func (this *LatLong) DistanceToSegmentNmE(start, end *LatLong) (float64, error) {
+	return sph.DistanceToSegmentNmE(start.latitude, start.longitude, end.latitude, end.longitude, this.latitude, this.longitude)
+}
@@ -0,0 +1,57 @@
+package latlong_test
+
+import (
+	"errors"
+	"math"
+	sph "stellarsunset/spherical"
+	ll "stellarsunset/spherical/latlong"
+	"testing"
+)
+
+func TestClosestPointOnSegment(t *testing.T) {
+
+	start, end := ll.NewLatLong(0., 0.), ll.NewLatLong(0., 10.)
+
+	closest, distance, location := ll.NewLatLong(1., 5.).ClosestPointOnSegment(start, end)
+	isEqual(t, sph.SegmentInterior, location)
+	withinError(t, 0., closest.Latitude(), 1e-9)
+	withinError(t, 5., closest.Longitude(), 1e-6)
+	withinError(t, 60.00686673640662, distance.InNauticalMiles(), .0001)
+
+	closest, _, location = ll.NewLatLong(1., 11.).ClosestPointOnSegment(start, end)
+	isEqual(t, sph.SegmentEnd, location)
+	isEqual(t, *end, *closest)
+}
+
+func TestDistanceToSegment(t *testing.T) {
+
+	start, end, point := ll.NewLatLong(0., 0.), ll.NewLatLong(0., 10.), ll.NewLatLong(0., -1.)
+
+	withinError(t, start.DistanceTo(point).InNauticalMiles(), point.DistanceToSegment(start, end).InNauticalMiles(), 1e-9)
+}
+
+func TestClosestPointOnSegmentE(t *testing.T) {
+
+	start, end := ll.NewLatLong(0., 0.), ll.NewLatLong(0., 10.)
+
+	closest, _, location, err := ll.NewLatLong(1., -1.).ClosestPointOnSegmentE(start, end)
+	isEqual(t, nil, err)
+	isEqual(t, sph.SegmentStart, location)
+	isEqual(t, *start, *closest)
+}
+
+func TestDistanceToSegmentE(t *testing.T) {
+
+	start, end, point := ll.NewLatLong(0., 0.), ll.NewLatLong(0., 10.), ll.NewLatLong(0., -1.)
+
+	distance, err := point.DistanceToSegmentE(start, end)
+	isEqual(t, nil, err)
+	withinError(t, start.DistanceTo(point).InNauticalMiles(), distance.InNauticalMiles(), 1e-9)
+
+	// The Great Circle through antipodal endpoints is undefined
+	_, err = point.DistanceToSegmentE(start, ll.Normalized(0., 180.))
+	isTrue(t, errors.Is(err, sph.ErrDegenerateTrack), "Antipodal endpoints")
+
+	_, err = ll.Normalized(math.NaN(), 0.).DistanceToSegmentNmE(start, end)
+	isTrue(t, errors.Is(err, sph.ErrNaNInput), "NaN position")
+}
@@ -0,0 +1,40 @@
+package magnetic
+
+import "math"
+
+// The geomagnetic field at a point, with components in nanoteslas along the north, east and down axes of the WGS-84 ellipsoid
+type Field struct {
+	north, east, down float64
+}
+
+func (this *Field) North() float64 {
+	return this.north
+}
+
+func (this *Field) East() float64 {
+	return this.east
+}
+
+func (this *Field) Down() float64 {
+	return this.down
+}
+
+// The strength of the horizontal component of the field, the part a compass aligns with
+func (this *Field) Horizontal() float64 {
+	return math.Hypot(this.north, this.east)
+}
+
+// The total strength of the field
+func (this *Field) Total() float64 {
+	return math.Hypot(this.Horizontal(), this.down)
+}
+
+// The angle (in degrees, positive east) from true north to the horizontal component of the field, i.e. to magnetic north
+func (this *Field) Declination() float64 {
+	return math.Atan2(this.east, this.north) * radiansToDegrees
+}
+
+// The angle (in degrees, positive down) from the horizontal to the field, also known as the dip
+func (this *Field) Inclination() float64 {
+	return math.Atan2(this.down, this.Horizontal()) * radiansToDegrees
+}
@@ -0,0 +1,286 @@
+/*
+This Magnetic package computes magnetic variation (the declination between true and magnetic north) from the World Magnetic Model
+(WMM), so courses from charts and pilot reports given relative to magnetic north can be compared with the true courses used
+throughout this library. Courses relative to grid north on the UTM and UPS grids are supported too.
+
+The WMM2025 coefficients published by NOAA and the British Geological Survey are embedded, and are valid from 2025 until 2030.
+Later coefficient files in the same format can be read with Load.
+
+See Chulliat et al., "The US/UK World Magnetic Model for 2025-2030: Technical Report" (2024).
+*/
+package magnetic
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	dist "stellarsunset/spherical/distance"
+	ell "stellarsunset/spherical/ellipsoid"
+	ll "stellarsunset/spherical/latlong"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidCoefficients = errors.New("Invalid magnetic model coefficients")
+	// Returned when a date is before the epoch of a model or after the five years it's valid for
+	ErrDateOutOfRange = errors.New("Date is outside the magnetic model's validity")
+)
+
+const (
+	// The radius in kilometers of the sphere the model's spherical harmonics are referenced to
+	referenceRadius float64 = 6371.2
+	// The number of years after its epoch a model is valid for
+	validYears float64 = 5.
+
+	degreesToRadians float64 = math.Pi / 180.
+	radiansToDegrees float64 = 180. / math.Pi
+)
+
+//go:embed WMM.COF
+var wmmCoefficients []byte
+
+var wmm = mustLoad(wmmCoefficients)
+
+// The embedded World Magnetic Model
+func WMM() *Model {
+	return wmm
+}
+
+// A Model is a spherical harmonic model of the main geomagnetic field and its secular variation, valid for five years from its
+// epoch
+type Model struct {
+	name  string
+	epoch float64
+	// Gauss coefficients and their yearly rate of change indexed by degree and order, scaled from the Schmidt semi-normalized
+	// values in coefficient files so they apply to unnormalized associated Legendre functions
+	g, h, gDot, hDot [][]float64
+}
+
+// Reads a model from a WMM coefficient (.COF) file, i.e. a header line with the epoch and model name followed by a line for each
+// degree and order with the coefficients and their secular variation, and ending in a line of 9s. Returns an error matching
+// ErrInvalidCoefficients if the file can't be read.
+func Load(reader io.Reader) (*Model, error) {
+
+	scanner := bufio.NewScanner(reader)
+
+	header := nextFields(scanner)
+	if len(header) < 2 {
+		return nil, fmt.Errorf("%w: missing header", ErrInvalidCoefficients)
+	}
+	epoch, err := strconv.ParseFloat(header[0], 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: epoch %q", ErrInvalidCoefficients, header[0])
+	}
+
+	var rows [][6]float64
+	degree := 0
+	for fields := nextFields(scanner); fields != nil && !strings.HasPrefix(fields[0], "9999"); fields = nextFields(scanner) {
+
+		var row [6]float64
+		if len(fields) != len(row) {
+			return nil, fmt.Errorf("%w: expected %d values in %q", ErrInvalidCoefficients, len(row), strings.Join(fields, " "))
+		}
+		for i, f := range fields {
+			if row[i], err = strconv.ParseFloat(f, 64); err != nil {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidCoefficients, f)
+			}
+		}
+
+		n, m := int(row[0]), int(row[1])
+		if n < 1 || m < 0 || n < m {
+			return nil, fmt.Errorf("%w: degree %d and order %d", ErrInvalidCoefficients, n, m)
+		}
+		if n > degree {
+			degree = n
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCoefficients, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: no coefficients", ErrInvalidCoefficients)
+	}
+
+	model := &Model{header[1], epoch, triangle(degree), triangle(degree), triangle(degree), triangle(degree)}
+	schmidt := schmidtFactors(degree)
+	for _, row := range rows {
+		n, m := int(row[0]), int(row[1])
+		s := schmidt[n][m]
+		model.g[n][m], model.h[n][m], model.gDot[n][m], model.hDot[n][m] = s*row[2], s*row[3], s*row[4], s*row[5]
+	}
+	return model, nil
+}
+
+func (this *Model) Name() string {
+	return this.name
+}
+
+// The maximum degree (and order) of the model's spherical harmonics
+func (this *Model) Degree() int {
+	return len(this.g) - 1
+}
+
+// The start of the model's validity
+func (this *Model) ValidFrom() time.Time {
+	return fromDecimalYear(this.epoch)
+}
+
+// The end of the model's validity, which is exclusive as it's the epoch of the next model
+func (this *Model) ValidUntil() time.Time {
+	return fromDecimalYear(this.epoch + validYears)
+}
+
+// Returns the magnetic field at the LatLong and altitude above the WGS-84 ellipsoid on the date, or an error matching
+// ErrDateOutOfRange if the model isn't valid then.
+func (this *Model) FieldAt(position *ll.LatLong, altitude *dist.Distance, date time.Time) (*Field, error) {
+
+	t := decimalYear(date)
+	if t < this.epoch || this.epoch+validYears <= t {
+		return nil, fmt.Errorf("%w: %s not within [%s, %s)", ErrDateOutOfRange, date.Format(time.RFC3339),
+			this.ValidFrom().Format(time.RFC3339), this.ValidUntil().Format(time.RFC3339))
+	}
+	return this.field(position, altitude, t-this.epoch), nil
+}
+
+// Returns the magnetic declination (in degrees, positive east) at the LatLong and altitude on the date, or an error matching
+// ErrDateOutOfRange if the model isn't valid then.
+func (this *Model) Declination(position *ll.LatLong, altitude *dist.Distance, date time.Time) (float64, error) {
+	field, err := this.FieldAt(position, altitude, date)
+	if err != nil {
+		return 0., err
+	}
+	return field.Declination(), nil
+}
+
+// Synthesizes the field the number of years after the epoch
+func (this *Model) field(position *ll.LatLong, altitude *dist.Distance, years float64) *Field {
+
+	// The geocentric radius (in km) and colatitude of the position, where the harmonics are evaluated
+	ecef := ell.WGS84().ToECEF(position, altitude).Times(1. / 1000.)
+	p := math.Hypot(ecef.X(), ecef.Y())
+	r := math.Hypot(p, ecef.Z())
+	cosTheta, sinTheta := ecef.Z()/r, p/r
+
+	// The eastward component is indeterminate at the poles, so it's evaluated just off them
+	sinTheta = math.Max(sinTheta, 1e-10)
+
+	lambda := position.Longitude() * degreesToRadians
+	pnm, dpnm := legendre(this.Degree(), cosTheta, sinTheta)
+
+	// The field in the directions of increasing radius, colatitude and longitude
+	var br, bTheta, bLambda float64
+	ratio := referenceRadius / r
+	scale := ratio * ratio
+	for n := 1; n <= this.Degree(); n++ {
+		scale *= ratio
+		for m := 0; m <= n; m++ {
+			g, h := this.g[n][m]+years*this.gDot[n][m], this.h[n][m]+years*this.hDot[n][m]
+			sinM, cosM := math.Sincos(float64(m) * lambda)
+
+			br += scale * float64(n+1) * (g*cosM + h*sinM) * pnm[n][m]
+			bTheta -= scale * (g*cosM + h*sinM) * dpnm[n][m]
+			bLambda -= scale * float64(m) * (h*cosM - g*sinM) * pnm[n][m]
+		}
+	}
+	bLambda /= sinTheta
+
+	// Rotate north and down from the geocentric to the geodetic latitude
+	psi := math.Atan2(ecef.Z(), p) - position.Latitude()*degreesToRadians
+	sinPsi, cosPsi := math.Sincos(psi)
+	north, down := -bTheta, -br
+
+	return &Field{north*cosPsi - down*sinPsi, bLambda, north*sinPsi + down*cosPsi}
+}
+
+// Computes the unnormalized associated Legendre functions of cos(theta) up to the degree, along with their derivatives with
+// respect to theta, indexed by degree and order
+func legendre(degree int, cosTheta, sinTheta float64) (p, dp [][]float64) {
+
+	p, dp = triangle(degree), triangle(degree)
+	p[0][0] = 1.
+
+	for n := 1; n <= degree; n++ {
+		for m := 0; m <= n; m++ {
+			if n == m {
+				p[n][m] = sinTheta * p[n-1][m-1]
+				dp[n][m] = sinTheta*dp[n-1][m-1] + cosTheta*p[n-1][m-1]
+				continue
+			}
+			p[n][m] = cosTheta * p[n-1][m]
+			dp[n][m] = cosTheta*dp[n-1][m] - sinTheta*p[n-1][m]
+			if m < n-1 {
+				k := float64((n-1)*(n-1)-m*m) / float64((2*n-1)*(2*n-3))
+				p[n][m] -= k * p[n-2][m]
+				dp[n][m] -= k * dp[n-2][m]
+			}
+		}
+	}
+	return p, dp
+}
+
+// The ratios of the Schmidt semi-normalized associated Legendre functions to the unnormalized ones, indexed by degree and order
+func schmidtFactors(degree int) [][]float64 {
+
+	s := triangle(degree)
+	s[0][0] = 1.
+
+	for n := 1; n <= degree; n++ {
+		s[n][0] = s[n-1][0] * float64(2*n-1) / float64(n)
+		for m := 1; m <= n; m++ {
+			double := 1.
+			if m == 1 {
+				double = 2.
+			}
+			s[n][m] = s[n][m-1] * math.Sqrt(float64(n-m+1)*double/float64(n+m))
+		}
+	}
+	return s
+}
+
+// A triangular table indexed by degree and order
+func triangle(degree int) [][]float64 {
+	table := make([][]float64, degree+1)
+	for n := range table {
+		table[n] = make([]float64, n+1)
+	}
+	return table
+}
+
+// The fields of the next non-blank line, or nil at the end of the input
+func nextFields(scanner *bufio.Scanner) []string {
+	for scanner.Scan() {
+		if fields := strings.Fields(scanner.Text()); len(fields) > 0 {
+			return fields
+		}
+	}
+	return nil
+}
+
+// The time as a year and the fraction of it that has passed, e.g. 2020.5 at the start of the 2nd of July 2020
+func decimalYear(date time.Time) float64 {
+	date = date.UTC()
+	start := time.Date(date.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	return float64(date.Year()) + float64(date.Sub(start))/float64(start.AddDate(1, 0, 0).Sub(start))
+}
+
+// Inverts decimalYear
+func fromDecimalYear(year float64) time.Time {
+	whole := math.Floor(year)
+	start := time.Date(int(whole), time.January, 1, 0, 0, 0, 0, time.UTC)
+	return start.Add(time.Duration((year - whole) * float64(start.AddDate(1, 0, 0).Sub(start))))
+}
+
+func mustLoad(coefficients []byte) *Model {
+	model, err := Load(bytes.NewReader(coefficients))
+	if err != nil {
+		panic(err)
+	}
+	return model
+}
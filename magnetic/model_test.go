@@ -0,0 +1,139 @@
+package magnetic_test
+
+import (
+	"errors"
+	"math"
+	dist "stellarsunset/spherical/distance"
+	ll "stellarsunset/spherical/latlong"
+	"stellarsunset/spherical/magnetic"
+	"strings"
+	"testing"
+	"time"
+)
+
+func isTrue(t *testing.T, condition bool, s string) {
+	if !condition {
+		t.Error(s)
+	}
+}
+
+func isEqual(t *testing.T, expected, actual any) {
+	if expected != actual {
+		t.Errorf("want = %+v, got = %+v", expected, actual)
+	}
+}
+
+func withinError(t *testing.T, expected, actual, tolerance float64) {
+	if math.Abs(expected-actual) > tolerance {
+		t.Errorf("want = %f, got = %f, tol = %f", expected, actual, tolerance)
+	}
+}
+
+// A date as a year and the fraction of it that has passed
+func yearFraction(year float64) time.Time {
+	whole := math.Floor(year)
+	start := time.Date(int(whole), time.January, 1, 0, 0, 0, 0, time.UTC)
+	return start.Add(time.Duration((year - whole) * float64(start.AddDate(1, 0, 0).Sub(start))))
+}
+
+func TestWMM(t *testing.T) {
+	model := magnetic.WMM()
+	isEqual(t, "WMM-2025", model.Name())
+	isEqual(t, 12, model.Degree())
+	isEqual(t, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), model.ValidFrom())
+	isEqual(t, time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC), model.ValidUntil())
+}
+
+func TestFieldAt(t *testing.T) {
+
+	// At a sample of the WMM2025 test points, with the altitude in kilometers
+	tests := []struct {
+		year, altitude, lat, lon                    float64
+		declination, inclination, north, east, down float64
+	}{
+		{2025.0, 28., 89., -121., -99.77, 88.47, -255.4, -1482.5, 56194.3},
+		{2025.0, 65., 43., 93., 0.50, 64.10, 24299.9, 210.5, 50037.9},
+		{2025.0, 51., -33., 109., -5.49, -67.50, 21737.8, -2090.3, -52710.0},
+		{2025.0, 18., 0., 21., 1.29, -26.06, 29274.8, 659.8, -14316.7},
+		{2025.5, 6., -36., -137., 20.28, -52.11, 23781.9, 8786.7, -32577.5},
+		{2025.5, 50., -81., -67., 28.13, -67.61, 16132.7, 8623.5, -44412.3},
+		{2026.0, 83., 86., -46., -30.61, 86.84, 2582.1, -1527.8, 54279.3},
+		{2026.5, 12., -79., 115., -137.58, -77.37, -9613.7, -8785.7, -58104.3},
+		{2027.0, 61., 59., -77., -16.48, 78.68, 10437.8, -3087.4, 54397.7},
+		{2027.5, 0., -13., -59., -17.49, -15.26, 21365.8, -6732.6, -6112.3},
+		{2028.5, 11., 34., 0., 1.57, 46.77, 29078.2, 798.4, 30945.6},
+		{2029.0, 50., 87., -154., -73.48, 89.07, 257.9, -869.5, 55992.3},
+		{2029.5, 77., -18., 138., 4.45, -47.55, 31751.5, 2472.3, -34817.4},
+	}
+
+	for _, test := range tests {
+		field, err := magnetic.WMM().FieldAt(ll.NewLatLong(test.lat, test.lon), dist.OfKilometers(test.altitude),
+			yearFraction(test.year))
+
+		isEqual(t, nil, err)
+		withinError(t, test.declination, field.Declination(), 0.01)
+		withinError(t, test.inclination, field.Inclination(), 0.01)
+		withinError(t, test.north, field.North(), 0.1)
+		withinError(t, test.east, field.East(), 0.1)
+		withinError(t, test.down, field.Down(), 0.1)
+		withinError(t, math.Hypot(test.north, test.east), field.Horizontal(), 0.2)
+		withinError(t, math.Sqrt(test.north*test.north+test.east*test.east+test.down*test.down), field.Total(), 0.2)
+	}
+}
+
+func TestFieldAtPole(t *testing.T) {
+	field, err := magnetic.WMM().FieldAt(ll.Normalized(90., 0.), dist.OfMeters(0.), yearFraction(2027.))
+	isEqual(t, nil, err)
+	isTrue(t, !math.IsNaN(field.East()) && !math.IsInf(field.East(), 0), "Field should be finite at the pole")
+	withinError(t, 90., field.Inclination(), 2.)
+}
+
+func TestFieldAtOutOfRange(t *testing.T) {
+	position, altitude := ll.NewLatLong(40., -74.), dist.OfMeters(0.)
+
+	_, err := magnetic.WMM().FieldAt(position, altitude, time.Date(2024, time.December, 31, 23, 59, 0, 0, time.UTC))
+	isTrue(t, errors.Is(err, magnetic.ErrDateOutOfRange), "Date before the epoch should be out of range")
+
+	_, err = magnetic.WMM().Declination(position, altitude, time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC))
+	isTrue(t, errors.Is(err, magnetic.ErrDateOutOfRange), "Model should not be valid at the next epoch")
+}
+
+func TestDeclination(t *testing.T) {
+	declination, err := magnetic.WMM().Declination(ll.NewLatLong(43., 93.), dist.OfKilometers(65.), yearFraction(2025.))
+	isEqual(t, nil, err)
+	withinError(t, 0.50, declination, 0.01)
+}
+
+func TestLoad(t *testing.T) {
+	cof := `    2030.0            WMM-TEST        01/01/2030
+  1  0  -30000.0       0.0        0.0        0.0
+  1  1       0.0       0.0        0.0        0.0
+999999999999999999999999999999999999999999999999
+999999999999999999999999999999999999999999999999
+`
+	model, err := magnetic.Load(strings.NewReader(cof))
+	isEqual(t, nil, err)
+	isEqual(t, "WMM-TEST", model.Name())
+	isEqual(t, 1, model.Degree())
+	isEqual(t, time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC), model.ValidFrom())
+
+	// A purely axial dipole points due north everywhere
+	field, err := model.FieldAt(ll.NewLatLong(45., 100.), dist.OfMeters(0.), yearFraction(2031.))
+	isEqual(t, nil, err)
+	withinError(t, 0., field.Declination(), 1e-9)
+	isTrue(t, field.North() > 0. && field.Down() > 0., "Field should point north and down in the northern hemisphere")
+}
+
+func TestLoadInvalid(t *testing.T) {
+	for _, cof := range []string{
+		"",
+		"WMM-2020\n",
+		"2020.0 WMM-2020\n",
+		"2020.0 WMM-2020\n 1 0 -29404.5 0.0 6.7\n",
+		"2020.0 WMM-2020\n 1 0 -29404.5 0.0 6.7 x\n",
+		"2020.0 WMM-2020\n 1 2 -29404.5 0.0 6.7 0.0\n",
+	} {
+		_, err := magnetic.Load(strings.NewReader(cof))
+		isTrue(t, errors.Is(err, magnetic.ErrInvalidCoefficients), "Should be invalid: "+cof)
+	}
+}
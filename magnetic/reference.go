@@ -0,0 +1,136 @@
+package magnetic
+
+import (
+	"fmt"
+	"math"
+	crs "stellarsunset/spherical/course"
+	dist "stellarsunset/spherical/distance"
+	ll "stellarsunset/spherical/latlong"
+	"stellarsunset/spherical/utm"
+	"time"
+)
+
+// The north a course is measured clockwise from
+type Reference int
+
+const (
+	// Geographic north, along the meridian towards the North Pole
+	True Reference = iota
+	// Magnetic north, the direction a compass points
+	Magnetic
+	// Grid north, along the northing axis of the UTM or UPS grid
+	Grid
+)
+
+var references = [...]string{
+	True:     "True",
+	Magnetic: "Magnetic",
+	Grid:     "Grid",
+}
+
+func (this Reference) String() string {
+	return references[this]
+}
+
+// A Bearing is a course along with the north it's measured from, so magnetic and grid courses aren't mistaken for true ones
+type Bearing struct {
+	course    *crs.Course
+	reference Reference
+}
+
+func NewBearing(course *crs.Course, reference Reference) *Bearing {
+	return &Bearing{course, reference}
+}
+
+func (this *Bearing) Course() *crs.Course {
+	return this.course
+}
+
+func (this *Bearing) Reference() Reference {
+	return this.reference
+}
+
+// Formats the bearing in degrees followed by the first letter of its reference, e.g. "095.0°M"
+func (this *Bearing) String() string {
+	return fmt.Sprintf("%05.1f°%c", this.course.InDegrees(), this.reference.String()[0])
+}
+
+// A Variation is the direction of magnetic and grid north relative to true north at a position and time
+type Variation struct {
+	declination, convergence float64
+}
+
+// Creates a new Variation from the magnetic declination and grid convergence, both in degrees clockwise from true north, e.g. as
+// printed on a chart
+func NewVariation(declination, convergence float64) *Variation {
+	return &Variation{declination, convergence}
+}
+
+// Returns the Variation at the LatLong and altitude on the date, with the grid convergence of the position's standard UTM zone
+// (or UPS), or an error matching ErrDateOutOfRange if the model isn't valid then.
+func (this *Model) VariationAt(position *ll.LatLong, altitude *dist.Distance, date time.Time) (*Variation, error) {
+	declination, err := this.Declination(position, altitude, date)
+	if err != nil {
+		return nil, err
+	}
+	return &Variation{declination, utm.FromLatLong(position).Convergence()}, nil
+}
+
+// The angle (in degrees, positive east) from true north to magnetic north
+func (this *Variation) Declination() float64 {
+	return this.declination
+}
+
+// The angle (in degrees, positive east) from true north to grid north
+func (this *Variation) Convergence() float64 {
+	return this.convergence
+}
+
+// Converts the bearing to one measured from the reference, in the range [0, 360) degrees
+func (this *Variation) Convert(bearing *Bearing, to Reference) *Bearing {
+	if bearing.reference == to {
+		return bearing
+	}
+	degrees := math.Mod(bearing.course.InDegrees()+this.north(bearing.reference)-this.north(to), 360.)
+	if degrees < 0. {
+		degrees += 360.
+	}
+	return &Bearing{crs.OfDegrees(degrees), to}
+}
+
+// The angle in degrees from true north to the north of the reference
+func (this *Variation) north(reference Reference) float64 {
+	switch reference {
+	case Magnetic:
+		return this.declination
+	case Grid:
+		return this.convergence
+	}
+	return 0.
+}
+
+// Converts the true course to a magnetic one at the LatLong and altitude on the date, or returns an error matching
+// ErrDateOutOfRange if the model isn't valid then.
+func (this *Model) ToMagnetic(course *crs.Course, position *ll.LatLong, altitude *dist.Distance,
+	date time.Time) (*crs.Course, error) {
+
+	return this.convert(NewBearing(course, True), Magnetic, position, altitude, date)
+}
+
+// Converts the magnetic course to a true one at the LatLong and altitude on the date, or returns an error matching
+// ErrDateOutOfRange if the model isn't valid then.
+func (this *Model) ToTrue(course *crs.Course, position *ll.LatLong, altitude *dist.Distance,
+	date time.Time) (*crs.Course, error) {
+
+	return this.convert(NewBearing(course, Magnetic), True, position, altitude, date)
+}
+
+func (this *Model) convert(bearing *Bearing, to Reference, position *ll.LatLong, altitude *dist.Distance,
+	date time.Time) (*crs.Course, error) {
+
+	variation, err := this.VariationAt(position, altitude, date)
+	if err != nil {
+		return nil, err
+	}
+	return variation.Convert(bearing, to).Course(), nil
+}
@@ -0,0 +1,80 @@
+package magnetic_test
+
+import (
+	"errors"
+	crs "stellarsunset/spherical/course"
+	dist "stellarsunset/spherical/distance"
+	ll "stellarsunset/spherical/latlong"
+	"stellarsunset/spherical/magnetic"
+	"stellarsunset/spherical/utm"
+	"testing"
+)
+
+func TestReferenceString(t *testing.T) {
+	isEqual(t, "True", magnetic.True.String())
+	isEqual(t, "Magnetic", magnetic.Magnetic.String())
+	isEqual(t, "Grid", magnetic.Grid.String())
+}
+
+func TestBearing(t *testing.T) {
+	bearing := magnetic.NewBearing(crs.OfDegrees(95.), magnetic.Magnetic)
+	isEqual(t, 95., bearing.Course().InDegrees())
+	isEqual(t, magnetic.Magnetic, bearing.Reference())
+	isEqual(t, "095.0°M", bearing.String())
+	isEqual(t, "270.5°T", magnetic.NewBearing(crs.OfDegrees(270.5), magnetic.True).String())
+}
+
+func TestConvert(t *testing.T) {
+
+	// 13 degrees west variation, with grid north 2 degrees east of true north
+	variation := magnetic.NewVariation(-13., 2.)
+
+	tests := []struct {
+		course   float64
+		from, to magnetic.Reference
+		expected float64
+	}{
+		{90., magnetic.True, magnetic.Magnetic, 103.},
+		{90., magnetic.True, magnetic.Grid, 88.},
+		{5., magnetic.Magnetic, magnetic.True, 352.},
+		{359., magnetic.Grid, magnetic.True, 1.},
+		{103., magnetic.Magnetic, magnetic.Grid, 88.},
+		{88., magnetic.Grid, magnetic.Magnetic, 103.},
+	}
+
+	for _, test := range tests {
+		converted := variation.Convert(magnetic.NewBearing(crs.OfDegrees(test.course), test.from), test.to)
+		isEqual(t, test.to, converted.Reference())
+		withinError(t, test.expected, converted.Course().InDegrees(), 1e-9)
+	}
+
+	bearing := magnetic.NewBearing(crs.OfDegrees(42.), magnetic.Grid)
+	isEqual(t, bearing, variation.Convert(bearing, magnetic.Grid))
+}
+
+func TestVariationAt(t *testing.T) {
+	position := ll.NewLatLong(-36., -137.)
+
+	variation, err := magnetic.WMM().VariationAt(position, dist.OfKilometers(6.), yearFraction(2025.5))
+	isEqual(t, nil, err)
+	withinError(t, 20.28, variation.Declination(), 0.01)
+	isEqual(t, utm.FromLatLong(position).Convergence(), variation.Convergence())
+
+	_, err = magnetic.WMM().VariationAt(position, dist.OfKilometers(6.), yearFraction(2031.))
+	isTrue(t, errors.Is(err, magnetic.ErrDateOutOfRange), "Variation should not be available outside the model")
+}
+
+func TestToMagneticAndTrue(t *testing.T) {
+	position, altitude, date := ll.NewLatLong(-36., -137.), dist.OfKilometers(6.), yearFraction(2025.5)
+
+	magneticCourse, err := magnetic.WMM().ToMagnetic(crs.OfDegrees(100.), position, altitude, date)
+	isEqual(t, nil, err)
+	withinError(t, 79.72, magneticCourse.InDegrees(), 0.01)
+
+	trueCourse, err := magnetic.WMM().ToTrue(magneticCourse, position, altitude, date)
+	isEqual(t, nil, err)
+	withinError(t, 100., trueCourse.InDegrees(), 1e-9)
+
+	_, err = magnetic.WMM().ToTrue(magneticCourse, position, altitude, yearFraction(2024.))
+	isTrue(t, errors.Is(err, magnetic.ErrDateOutOfRange), "Conversion should fail outside the model")
+}
@@ -0,0 +1,209 @@
+/*
+This MGRS package encodes LatLongs as Military Grid Reference System (MGRS) strings, e.g. "18TWL8354007264", and decodes them
+back. The US National Grid (USNG) uses the same references on WGS-84 so is supported too.
+
+An MGRS reference is a UTM zone and latitude band (or a UPS band in the polar regions), a pair of letters identifying a 100km
+square within it, and an even number of digits locating a cell within the square, from 100km for no digits down to 1m for ten.
+*/
+package mgrs
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	dist "stellarsunset/spherical/distance"
+	ll "stellarsunset/spherical/latlong"
+	"stellarsunset/spherical/utm"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// The most digits supported for each of the easting and northing, i.e. 1m cells
+const MaxPrecision int = 5
+
+var (
+	ErrInvalidPrecision = errors.New("MGRS precision is out of range [0, 5]")
+	ErrInvalidReference = errors.New("Invalid MGRS reference")
+)
+
+const (
+	square float64 = 100000.
+	// Row letters repeat every 2,000km of northing
+	rowCycle float64 = 2000000.
+
+	utmBands string = "CDEFGHJKLMNPQRSTUVWX"
+	utmRows  string = "ABCDEFGHJKLMNPQRSTUV"
+)
+
+// Column letters for UTM zones in sets of three, each covering the eight 100km squares from easting 100km to 900km
+var utmColumns = [3]string{"ABCDEFGH", "JKLMNPQR", "STUVWXYZ"}
+
+// Encodes the LatLong as an MGRS reference with the provided number of digits (0-5) for each of the easting and northing,
+// panicking if ToMGRSE would return an error. Positions are truncated (not rounded) to the south-west corner of the cell they
+// fall in, as is conventional.
+func ToMGRS(position *ll.LatLong, precision int) string {
+	reference, err := ToMGRSE(position, precision)
+	if err != nil {
+		panic(err)
+	}
+	return reference
+}
+
+// Encodes the LatLong as an MGRS reference as ToMGRS does, or returns an error matching ErrInvalidPrecision if the precision is
+// outside [0, MaxPrecision].
+func ToMGRSE(position *ll.LatLong, precision int) (string, error) {
+	if precision < 0 || MaxPrecision < precision {
+		return "", fmt.Errorf("%w: %d", ErrInvalidPrecision, precision)
+	}
+
+	c := utm.FromLatLong(position)
+	easting, northing := c.Easting().InMeters(), c.Northing().InMeters()
+
+	var prefix string
+	if c.IsUPS() {
+		prefix = upsSquare(c.Hemisphere(), easting, northing)
+	} else {
+		zone := c.Zone()
+		column := utmColumns[(zone-1)%3][int(math.Floor(easting/square))-1]
+		row := utmRows[(int(math.Floor(northing/square))+rowOffset(zone))%len(utmRows)]
+		// Taken from the position itself as the band of the grid coordinate is subject to round-off at the band boundaries
+		band := utmBands[int(math.Min(19., math.Floor(position.Latitude()/8.+10.)))]
+		prefix = fmt.Sprintf("%d%c%c%c", zone, band, column, row)
+	}
+
+	cell := math.Pow(10., float64(MaxPrecision-precision))
+	e := int(math.Floor(math.Mod(easting, square) / cell))
+	n := int(math.Floor(math.Mod(northing, square) / cell))
+
+	if precision == 0 {
+		return prefix, nil
+	}
+	return fmt.Sprintf("%s%0*d%0*d", prefix, precision, e, precision, n), nil
+}
+
+// Decodes the MGRS reference returning the south-west corner and the center of the cell it identifies, or an error matching
+// ErrInvalidReference if it is malformed. Letters may be upper or lower case, and spaces between the parts are ignored, e.g.
+// "18T WL 83540 07264".
+func FromMGRS(reference string) (southWest, center *ll.LatLong, err error) {
+
+	compact := strings.ToUpper(strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, reference))
+
+	fail := func(reason string, args ...any) (*ll.LatLong, *ll.LatLong, error) {
+		return nil, nil, fmt.Errorf("%w %q: %s", ErrInvalidReference, reference, fmt.Sprintf(reason, args...))
+	}
+
+	// Split off the zone, the letters and the digits
+	digitsStart := len(compact)
+	for digitsStart > 0 && unicode.IsDigit(rune(compact[digitsStart-1])) {
+		digitsStart--
+	}
+	zoneEnd := 0
+	for zoneEnd < len(compact) && unicode.IsDigit(rune(compact[zoneEnd])) {
+		zoneEnd++
+	}
+	if digitsStart-zoneEnd != 3 {
+		return fail("expected a band letter and two 100km square letters")
+	}
+	band, column, row := compact[zoneEnd], compact[zoneEnd+1], compact[zoneEnd+2]
+
+	digits := compact[digitsStart:]
+	if len(digits)%2 != 0 || len(digits) > 2*MaxPrecision {
+		return fail("expected an even number of digits, at most %d", 2*MaxPrecision)
+	}
+	precision := len(digits) / 2
+	cell := math.Pow(10., float64(MaxPrecision-precision))
+
+	e, n := 0., 0.
+	if precision > 0 {
+		ei, _ := strconv.Atoi(digits[:precision])
+		ni, _ := strconv.Atoi(digits[precision:])
+		e, n = float64(ei)*cell, float64(ni)*cell
+	}
+
+	var zone int
+	var hemisphere utm.Hemisphere
+	var squareEasting, squareNorthing float64
+
+	if zoneEnd == 0 {
+		hemisphere, squareEasting, squareNorthing, err = upsSquareOrigin(band, column, row)
+		if err != nil {
+			return fail(err.Error())
+		}
+		zone = utm.UPS
+	} else {
+		zone, _ = strconv.Atoi(compact[:zoneEnd])
+		if zone < 1 || 60 < zone {
+			return fail("zone %d is out of range [1, 60]", zone)
+		}
+		hemisphere, squareEasting, squareNorthing, err = utmSquareOrigin(zone, band, column, row, n+cell)
+		if err != nil {
+			return fail(err.Error())
+		}
+	}
+
+	corner := utm.NewCoordinate(zone, hemisphere, dist.OfMeters(squareEasting+e), dist.OfMeters(squareNorthing+n))
+	middle := utm.NewCoordinate(zone, hemisphere, dist.OfMeters(squareEasting+e+cell/2.), dist.OfMeters(squareNorthing+n+cell/2.))
+	return corner.ToLatLong(), middle.ToLatLong(), nil
+}
+
+// Row letters in even zones are offset by five so squares sharing a corner across zones have different letters
+func rowOffset(zone int) int {
+	if zone%2 == 0 {
+		return 5
+	}
+	return 0
+}
+
+// Computes the easting and northing of the south-west corner of the 100km square identified by the letters in the UTM zone,
+// where the top of the cell is the given distance north of the square's southern edge.
+func utmSquareOrigin(zone int, band, column, row byte, cellTop float64) (utm.Hemisphere, float64, float64, error) {
+
+	b := strings.IndexByte(utmBands, band)
+	if b < 0 {
+		return 0, 0., 0., fmt.Errorf("invalid latitude band %c", band)
+	}
+	c := strings.IndexByte(utmColumns[(zone-1)%3], column)
+	if c < 0 {
+		return 0, 0., 0., fmt.Errorf("invalid column %c for zone %d", column, zone)
+	}
+	r := strings.IndexByte(utmRows, row)
+	if r < 0 {
+		return 0, 0., 0., fmt.Errorf("invalid row %c", row)
+	}
+
+	hemisphere := utm.Northern
+	if band < 'N' {
+		hemisphere = utm.Southern
+	}
+
+	easting := float64(c+1) * square
+	northing := float64((r-rowOffset(zone)+len(utmRows))%len(utmRows)) * square
+
+	// Row letters only identify the northing modulo 2,000km, the band identifies which cycle it's in. Bands are smaller than that
+	// so the first cycle where the cell reaches the bottom of the band is the right one.
+	bottom := bandBottomNorthing(zone, b)
+	for northing+cellTop <= bottom {
+		northing += rowCycle
+	}
+	return hemisphere, easting, northing, nil
+}
+
+// The lowest northing along the southern edge of the latitude band within the zone, taken as the lower of that at the central
+// meridian and six degrees from it to allow for the widened Norway and Svalbard zones.
+func bandBottomNorthing(zone, band int) float64 {
+	lat := -80. + 8.*float64(band)
+	meridian := float64(zone)*6. - 183.
+
+	lowest := math.Inf(1)
+	for _, lon := range []float64{meridian, meridian + 6.} {
+		c, _ := utm.FromLatLongInZone(ll.Normalized(lat, lon), zone)
+		lowest = math.Min(lowest, c.Northing().InMeters())
+	}
+	return lowest
+}
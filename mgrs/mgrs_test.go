@@ -0,0 +1,152 @@
+package mgrs_test
+
+import (
+	"errors"
+	"math"
+	dist "stellarsunset/spherical/distance"
+	ll "stellarsunset/spherical/latlong"
+	"stellarsunset/spherical/mgrs"
+	"stellarsunset/spherical/utm"
+	"testing"
+)
+
+func isTrue(t *testing.T, condition bool, s string) {
+	if !condition {
+		t.Error(s)
+	}
+}
+
+func isEqual(t *testing.T, expected, actual any) {
+	if expected != actual {
+		t.Errorf("want = %+v, got = %+v", expected, actual)
+	}
+}
+
+func withinError(t *testing.T, expected, actual, tolerance float64) {
+	if math.Abs(expected-actual) > tolerance {
+		t.Errorf("want = %f, got = %f, tol = %f", expected, actual, tolerance)
+	}
+}
+
+func TestFromMGRS(t *testing.T) {
+
+	southWest, center, err := mgrs.FromMGRS("18TWL8354007264")
+	isTrue(t, err == nil, "Error")
+
+	// Zone 18 uses columns S-Z so W is the fifth 100km square east, and rows start at F so L is the fifth north (plus two
+	// cycles of 2,000km to reach band T)
+	expected := utm.NewCoordinate(18, utm.Northern, dist.OfMeters(583540.), dist.OfMeters(4507264.)).ToLatLong()
+	withinError(t, expected.Latitude(), southWest.Latitude(), 1e-12)
+	withinError(t, expected.Longitude(), southWest.Longitude(), 1e-12)
+
+	// The center of a 1m cell is half a meter north and east
+	c := utm.FromLatLong(center)
+	withinError(t, 583540.5, c.Easting().InMeters(), 1e-6)
+	withinError(t, 4507264.5, c.Northing().InMeters(), 1e-6)
+}
+
+func TestFromMGRSFormats(t *testing.T) {
+
+	expected, _, _ := mgrs.FromMGRS("18TWL8354007264")
+
+	for _, s := range []string{"18T WL 83540 07264", "18t wl 8354007264", " 18TWL 83540 07264 "} {
+		southWest, _, err := mgrs.FromMGRS(s)
+		isTrue(t, err == nil, "Error")
+		isEqual(t, *expected, *southWest)
+	}
+}
+
+func TestFromMGRSPrecision(t *testing.T) {
+
+	// A 10km cell
+	southWest, center, _ := mgrs.FromMGRS("18TWL80")
+
+	sw, c := utm.FromLatLong(southWest), utm.FromLatLong(center)
+	withinError(t, 580000., sw.Easting().InMeters(), 1e-6)
+	withinError(t, 4500000., sw.Northing().InMeters(), 1e-6)
+	withinError(t, 585000., c.Easting().InMeters(), 1e-6)
+	withinError(t, 4505000., c.Northing().InMeters(), 1e-6)
+}
+
+func TestFromMGRSSouthern(t *testing.T) {
+
+	// Sydney
+	p := ll.NewLatLong(-33.8688, 151.2093)
+	reference := mgrs.ToMGRS(p, 5)
+	isEqual(t, "56H", reference[:3])
+
+	southWest, _, err := mgrs.FromMGRS(reference)
+	isTrue(t, err == nil, "Error")
+	isTrue(t, p.DistanceTo(southWest).InMeters() < 1.5, "Within cell")
+}
+
+func TestFromMGRSInvalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"18TWL835400726",    // odd number of digits
+		"18TWL835400726412", // too many digits
+		"61TWL8354007264",   // zone out of range
+		"18IWL8354007264",   // invalid band
+		"18TAL8354007264",   // column from another zone's set
+		"18TWW8354007264",   // invalid row
+		"18T8354007264",     // missing square
+		"18TWL83540X7264",   // non-digit
+	} {
+		_, _, err := mgrs.FromMGRS(s)
+		isTrue(t, errors.Is(err, mgrs.ErrInvalidReference), s)
+	}
+}
+
+func TestToMGRS(t *testing.T) {
+
+	p, _, _ := mgrs.FromMGRS("18TWL8354007264")
+	center := utm.NewCoordinate(18, utm.Northern, dist.OfMeters(583540.5), dist.OfMeters(4507264.5)).ToLatLong()
+
+	isEqual(t, "18TWL8354007264", mgrs.ToMGRS(center, 5))
+	isEqual(t, "18TWL835072", mgrs.ToMGRS(center, 3))
+	isEqual(t, "18TWL", mgrs.ToMGRS(center, 0))
+	isTrue(t, p != nil, "Decoded")
+}
+
+func TestToMGRSInvalidPrecision(t *testing.T) {
+	defer func() {
+		err, _ := recover().(error)
+		isTrue(t, errors.Is(err, mgrs.ErrInvalidPrecision), "Precision")
+	}()
+	mgrs.ToMGRS(ll.NewLatLong(0., 0.), 6)
+}
+
+func TestToMGRSE(t *testing.T) {
+	reference, err := mgrs.ToMGRSE(ll.NewLatLong(0., 3.), 0)
+	isTrue(t, err == nil, "Error")
+	isEqual(t, mgrs.ToMGRS(ll.NewLatLong(0., 3.), 0), reference)
+
+	_, err = mgrs.ToMGRSE(ll.NewLatLong(0., 0.), -1)
+	isTrue(t, errors.Is(err, mgrs.ErrInvalidPrecision), "Precision")
+}
+
+func TestRoundTrip(t *testing.T) {
+	// Positions are chosen to stay clear of the band and zone boundaries, where cells straddle two of them
+	for lat := -79.7; lat < 84.; lat += 3.1 {
+		for lon := -178.9; lon < 180.; lon += 7.5 {
+			p := ll.NewLatLong(lat, lon)
+			for precision := 0; precision <= mgrs.MaxPrecision; precision++ {
+				reference := mgrs.ToMGRS(p, precision)
+
+				southWest, center, err := mgrs.FromMGRS(reference)
+				if err != nil {
+					t.Errorf("%s: %v", reference, err)
+					continue
+				}
+
+				// The center encodes to the same reference (unless the cell straddles a zone boundary) and the position is within
+				// the cell's diagonal of its corner
+				if precision >= 3 {
+					isEqual(t, reference, mgrs.ToMGRS(center, precision))
+				}
+				cell := math.Pow(10., float64(5-precision))
+				isTrue(t, p.DistanceTo(southWest).InMeters() <= 1.01*math.Sqrt2*cell, reference)
+			}
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package mgrs
+
+import (
+	"fmt"
+	"math"
+	"stellarsunset/spherical/utm"
+	"strings"
+)
+
+const (
+	// Columns west of the pole start at 800km of easting and those east of it at 2,000km
+	upsWestColumns string = "JKLPQRSTUXYZ"
+	upsEastColumns string = "ABCFGHJKLPQR"
+	upsRows        string = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+
+	upsWestEasting   float64 = 800000.
+	upsEastEasting   float64 = 2000000.
+	upsNorthNorthing float64 = 1300000.
+	upsSouthNorthing float64 = 800000.
+	// The northern polar cap is smaller, so uses fewer rows
+	upsNorthRows int = 14
+)
+
+// Returns the band and 100km square letters for the UPS coordinate
+func upsSquare(hemisphere utm.Hemisphere, easting, northing float64) string {
+
+	bands, columns, originEasting, originNorthing := "AB", upsWestColumns, upsWestEasting, upsSouthNorthing
+	if hemisphere == utm.Northern {
+		bands, originNorthing = "YZ", upsNorthNorthing
+	}
+
+	band := bands[0]
+	if easting >= upsEastEasting {
+		band, columns, originEasting = bands[1], upsEastColumns, upsEastEasting
+	}
+
+	column := columns[int(math.Floor((easting-originEasting)/square))]
+	row := upsRows[int(math.Floor((northing-originNorthing)/square))]
+	return fmt.Sprintf("%c%c%c", band, column, row)
+}
+
+// Computes the hemisphere along with the easting and northing of the south-west corner of the 100km square identified by the
+// UPS band and square letters
+func upsSquareOrigin(band, column, row byte) (utm.Hemisphere, float64, float64, error) {
+
+	var hemisphere utm.Hemisphere
+	var columns string
+	var originEasting, originNorthing float64
+	rows := upsRows
+
+	switch band {
+	case 'A', 'B':
+		hemisphere, originNorthing = utm.Southern, upsSouthNorthing
+	case 'Y', 'Z':
+		hemisphere, originNorthing, rows = utm.Northern, upsNorthNorthing, upsRows[:upsNorthRows]
+	default:
+		return 0, 0., 0., fmt.Errorf("invalid polar band %c", band)
+	}
+
+	if band == 'A' || band == 'Y' {
+		columns, originEasting = upsWestColumns, upsWestEasting
+	} else {
+		columns, originEasting = upsEastColumns, upsEastEasting
+	}
+
+	c := strings.IndexByte(columns, column)
+	if c < 0 {
+		return 0, 0., 0., fmt.Errorf("invalid column %c for band %c", column, band)
+	}
+	r := strings.IndexByte(rows, row)
+	if r < 0 {
+		return 0, 0., 0., fmt.Errorf("invalid row %c for band %c", row, band)
+	}
+	return hemisphere, originEasting + float64(c)*square, originNorthing + float64(r)*square, nil
+}
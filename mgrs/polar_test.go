@@ -0,0 +1,50 @@
+package mgrs_test
+
+import (
+	ll "stellarsunset/spherical/latlong"
+	"stellarsunset/spherical/mgrs"
+	"testing"
+)
+
+func TestPoles(t *testing.T) {
+	isEqual(t, "ZAH0000000000", mgrs.ToMGRS(ll.Normalized(90., 0.), 5))
+	isEqual(t, "BAN0000000000", mgrs.ToMGRS(ll.Normalized(-90., 0.), 5))
+
+	northPole, _, err := mgrs.FromMGRS("ZAH0000000000")
+	isTrue(t, err == nil, "Error")
+	withinError(t, 90., northPole.Latitude(), 1e-9)
+
+	southPole, _, _ := mgrs.FromMGRS("BAN 00000 00000")
+	withinError(t, -90., southPole.Latitude(), 1e-9)
+}
+
+func TestPolarBands(t *testing.T) {
+	isEqual(t, byte('Y'), mgrs.ToMGRS(ll.NewLatLong(85., -90.), 0)[0])
+	isEqual(t, byte('Z'), mgrs.ToMGRS(ll.NewLatLong(85., 90.), 0)[0])
+	isEqual(t, byte('A'), mgrs.ToMGRS(ll.NewLatLong(-85., -90.), 0)[0])
+	isEqual(t, byte('B'), mgrs.ToMGRS(ll.NewLatLong(-85., 90.), 0)[0])
+}
+
+func TestPolarRoundTrip(t *testing.T) {
+	for _, lat := range []float64{-89.9, -85., -80.5, 84.1, 87., 89.9} {
+		for lon := -179.5; lon < 180.; lon += 12.7 {
+			p := ll.NewLatLong(lat, lon)
+			reference := mgrs.ToMGRS(p, 5)
+
+			southWest, center, err := mgrs.FromMGRS(reference)
+			if err != nil {
+				t.Errorf("%s: %v", reference, err)
+				continue
+			}
+			isEqual(t, reference, mgrs.ToMGRS(center, 5))
+			isTrue(t, p.DistanceTo(southWest).InMeters() < 1.5, reference)
+		}
+	}
+}
+
+func TestPolarInvalid(t *testing.T) {
+	for _, s := range []string{"ZDH0000000000", "YAH0000000000", "ZAQ0000000000", "CAH0000000000"} {
+		_, _, err := mgrs.FromMGRS(s)
+		isTrue(t, err != nil, s)
+	}
+}
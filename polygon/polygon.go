@@ -0,0 +1,166 @@
+/*
+This Polygon package models regions on the surface of a spherical Earth (e.g. airspace sectors or maritime zones) bounded by
+Great Circle edges between a sequence of LatLong vertices.
+
+The edges of a polygon split the sphere into two regions, the polygon is always taken to be the smaller of the two. This means
+the order (clockwise vs counter-clockwise) of the vertices doesn't matter, and polygons encircling a pole contain that pole, but
+polygons covering more than a hemisphere can't be represented.
+
+All computations are performed with n-vectors so edges crossing the antimeridian or passing near the poles need no special care.
+*/
+package polygon
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	sph "stellarsunset/spherical"
+	"stellarsunset/spherical/area"
+	dist "stellarsunset/spherical/distance"
+	ll "stellarsunset/spherical/latlong"
+	vec "stellarsunset/spherical/vector"
+)
+
+// Returned when attempting to create a polygon with fewer than three distinct vertices
+var ErrTooFewVertices = errors.New("Polygon requires at least 3 vertices")
+
+type Polygon struct {
+	vertices []*ll.LatLong
+	vectors  []*vec.Vec3
+	// Whether the polygon is the region to the left of its edges when travelling through the vertices in order
+	left bool
+	// The solid angle (in steradians) enclosed by the polygon
+	excess float64
+}
+
+// Creates a new Polygon from the provided vertices, panicking if TryNewPolygon would return an error. The final vertex may repeat
+// the first (as is common in e.g. GeoJSON) but doesn't need to, the polygon is always closed.
+func NewPolygon(vertices ...*ll.LatLong) *Polygon {
+	polygon, err := TryNewPolygon(vertices...)
+	if err != nil {
+		panic(err)
+	}
+	return polygon
+}
+
+// Creates a new Polygon from the provided vertices, dropping any that repeat the previous one (including a closing vertex repeating
+// the first) as they form edges of zero length. Returns ErrTooFewVertices if fewer than three distinct vertices remain.
+func TryNewPolygon(vertices ...*ll.LatLong) (*Polygon, error) {
+
+	vertices = withoutRepeats(vertices)
+	if len(vertices) < 3 {
+		return nil, fmt.Errorf("%w: got %d", ErrTooFewVertices, len(vertices))
+	}
+
+	vectors := make([]*vec.Vec3, len(vertices))
+	for i, v := range vertices {
+		vectors[i] = v.ToNVector()
+	}
+
+	polygon := &Polygon{vertices: append([]*ll.LatLong(nil), vertices...), vectors: vectors}
+
+	// By Gauss-Bonnet the region to the left of the edges encloses 2pi minus the total turning angle
+	leftExcess := 2.*math.Pi - polygon.turning()
+	if leftExcess <= 2.*math.Pi {
+		polygon.left, polygon.excess = true, leftExcess
+	} else {
+		polygon.left, polygon.excess = false, 4.*math.Pi-leftExcess
+	}
+	return polygon, nil
+}
+
+// Returns the vertices without any equal to the one before it, treating the first as following the last
+func withoutRepeats(vertices []*ll.LatLong) []*ll.LatLong {
+	var distinct []*ll.LatLong
+	for _, v := range vertices {
+		if len(distinct) == 0 || *distinct[len(distinct)-1] != *v {
+			distinct = append(distinct, v)
+		}
+	}
+	for len(distinct) > 1 && *distinct[0] == *distinct[len(distinct)-1] {
+		distinct = distinct[:len(distinct)-1]
+	}
+	return distinct
+}
+
+// The vertices of the polygon, excluding any repeated or closing vertices
+func (this *Polygon) Vertices() []*ll.LatLong {
+	return append([]*ll.LatLong(nil), this.vertices...)
+}
+
+// The area enclosed by the polygon, computed from its spherical excess
+func (this *Polygon) Area() *area.Area {
+	return area.OfSquareNauticalMiles(this.excess * sph.EarthRadiusNm * sph.EarthRadiusNm)
+}
+
+// The total length of the edges of the polygon
+func (this *Polygon) Perimeter() *dist.Distance {
+	nm, n := 0., len(this.vertices)
+	for i := 0; i < n; i++ {
+		nm += this.vertices[i].DistanceInNm(this.vertices[(i+1)%n])
+	}
+	return dist.OfNauticalMiles(nm)
+}
+
+// Returns true if the provided LatLong falls within the polygon, the result for points exactly on an edge is undefined.
+func (this *Polygon) Contains(position *ll.LatLong) bool {
+	return this.isLeft(position.ToNVector()) == this.left
+}
+
+// Determines whether the point is in the region to the left of the edges by counting the edges crossed travelling from it to the
+// middle of an edge, whose side the journey ends on is known.
+func (this *Polygon) isLeft(p *vec.Vec3) bool {
+
+	n := len(this.vectors)
+	for i := 0; i < n; i++ {
+		a, b := this.vectors[i], this.vectors[(i+1)%n]
+
+		normal := a.Cross(b)
+		mid := a.Plus(b).Normalize()
+		side := p.Dot(normal)
+
+		// Try another edge if the point is on this edge's great circle or opposite its middle
+		if side == 0. || p.Dot(mid) <= -1.+antipodalTolerance {
+			continue
+		}
+
+		crossings := 0
+		for j := 0; j < n; j++ {
+			if j != i && crosses(p, mid, this.vectors[j], this.vectors[(j+1)%n]) {
+				crossings++
+			}
+		}
+		return (side > 0.) == (crossings%2 == 0)
+	}
+	return false
+}
+
+// The sum of the signed turning angles at each vertex, positive for left turns
+func (this *Polygon) turning() float64 {
+	total, n := 0., len(this.vectors)
+	for i := 0; i < n; i++ {
+		prev, curr, next := this.vectors[(i+n-1)%n], this.vectors[i], this.vectors[(i+1)%n]
+		total += prev.Cross(curr).SignedAngleTo(curr.Cross(next), curr)
+	}
+	return total
+}
+
+// Tolerance on the dot product of two unit vectors above -1 within which they are considered antipodal
+const antipodalTolerance float64 = 1e-12
+
+// Returns true if the minor Great Circle arc from a to b crosses the minor arc from c to d
+func crosses(a, b, c, d *vec.Vec3) bool {
+
+	ab, cd := a.Cross(b), c.Cross(d)
+
+	if (c.Dot(ab) > 0.) == (d.Dot(ab) > 0.) || (a.Dot(cd) > 0.) == (b.Dot(cd) > 0.) {
+		return false
+	}
+
+	// The great circles cross at two antipodal points, check the arcs contain the same one
+	x := ab.Cross(cd)
+	if x.Dot(a.Plus(b)) < 0. {
+		x = x.Negate()
+	}
+	return x.Dot(c.Plus(d)) > 0.
+}
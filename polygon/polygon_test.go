@@ -0,0 +1,144 @@
+package polygon_test
+
+import (
+	"errors"
+	"math"
+	sph "stellarsunset/spherical"
+	ll "stellarsunset/spherical/latlong"
+	"stellarsunset/spherical/polygon"
+	"testing"
+)
+
+func isTrue(t *testing.T, condition bool, s string) {
+	if !condition {
+		t.Error(s)
+	}
+}
+
+func isFalse(t *testing.T, condition bool, s string) {
+	if condition {
+		t.Error(s)
+	}
+}
+
+func withinError(t *testing.T, expected, actual, maxError float64, s string) {
+	if math.Abs(expected-actual) > maxError {
+		t.Errorf("%s: want = %f, got = %f, tol = %f", s, expected, actual, maxError)
+	}
+}
+
+func octant() []*ll.LatLong {
+	return []*ll.LatLong{ll.NewLatLong(0., 0.), ll.NewLatLong(0., 90.), ll.Normalized(90., 0.)}
+}
+
+func reversed(vertices []*ll.LatLong) []*ll.LatLong {
+	r := make([]*ll.LatLong, len(vertices))
+	for i, v := range vertices {
+		r[len(vertices)-1-i] = v
+	}
+	return r
+}
+
+func TestTryNewPolygon(t *testing.T) {
+
+	_, err := polygon.TryNewPolygon(ll.NewLatLong(0., 0.), ll.NewLatLong(1., 1.))
+	isTrue(t, errors.Is(err, polygon.ErrTooFewVertices), "Two vertices")
+
+	// A closing vertex doesn't count
+	_, err = polygon.TryNewPolygon(ll.NewLatLong(0., 0.), ll.NewLatLong(1., 1.), ll.NewLatLong(0., 0.))
+	isTrue(t, errors.Is(err, polygon.ErrTooFewVertices), "Closed two vertices")
+
+	// Nor do repeated ones, which would otherwise form edges of zero length
+	_, err = polygon.TryNewPolygon(ll.NewLatLong(0., 0.), ll.NewLatLong(1., 1.), ll.NewLatLong(1., 1.), ll.NewLatLong(0., 0.))
+	isTrue(t, errors.Is(err, polygon.ErrTooFewVertices), "Repeated vertices")
+
+	p, err := polygon.TryNewPolygon(octant()...)
+	isTrue(t, err == nil, "Octant")
+	isTrue(t, len(p.Vertices()) == 3, "Vertices")
+
+	o := octant()
+	repeated, err := polygon.TryNewPolygon(o[0], o[0], o[1], o[2], o[2], o[0], o[0])
+	isTrue(t, err == nil, "Repeated octant")
+	isTrue(t, len(repeated.Vertices()) == 3, "Repeated vertices dropped")
+	withinError(t, p.Area().InSquareNauticalMiles(), repeated.Area().InSquareNauticalMiles(), 1e-6, "Repeated area")
+	isTrue(t, repeated.Contains(ll.NewLatLong(30., 30.)), "Repeated octant contains")
+}
+
+func TestAreaOctant(t *testing.T) {
+
+	// One eighth of the sphere
+	expected := 4. * math.Pi * sph.EarthRadiusNm * sph.EarthRadiusNm / 8.
+
+	withinError(t, expected, polygon.NewPolygon(octant()...).Area().InSquareNauticalMiles(), 1e-6, "Area")
+	withinError(t, expected, polygon.NewPolygon(reversed(octant())...).Area().InSquareNauticalMiles(), 1e-6, "Reversed Area")
+}
+
+func TestAreaSmallSquare(t *testing.T) {
+
+	square := polygon.NewPolygon(ll.NewLatLong(0., 0.), ll.NewLatLong(0., 1.), ll.NewLatLong(1., 1.), ll.NewLatLong(1., 0.))
+
+	// Very nearly a lat/lon box whose area is R^2 * dLon * (sin(lat2) - sin(lat1))
+	expected := sph.EarthRadiusNm * sph.EarthRadiusNm * (math.Pi / 180.) * math.Sin(math.Pi/180.)
+	withinError(t, expected, square.Area().InSquareNauticalMiles(), 1e-3*expected, "Area")
+}
+
+func TestPerimeter(t *testing.T) {
+
+	expected := 3. * (math.Pi / 2.) * sph.EarthRadiusNm
+	withinError(t, expected, polygon.NewPolygon(octant()...).Perimeter().InNauticalMiles(), 1e-6, "Perimeter")
+}
+
+func TestContains(t *testing.T) {
+
+	square := polygon.NewPolygon(ll.NewLatLong(0., 0.), ll.NewLatLong(0., 1.), ll.NewLatLong(1., 1.), ll.NewLatLong(1., 0.))
+
+	isTrue(t, square.Contains(ll.NewLatLong(.5, .5)), "Center")
+	isFalse(t, square.Contains(ll.NewLatLong(1.5, .5)), "North")
+	isFalse(t, square.Contains(ll.NewLatLong(.5, -.5)), "West")
+	isFalse(t, square.Contains(ll.NewLatLong(-.5, -179.5)), "Antipode")
+}
+
+func TestContainsConcave(t *testing.T) {
+
+	// A "U" shape opening to the north
+	u := polygon.NewPolygon(
+		ll.NewLatLong(0., 0.), ll.NewLatLong(0., 3.), ll.NewLatLong(3., 3.), ll.NewLatLong(3., 2.),
+		ll.NewLatLong(1., 2.), ll.NewLatLong(1., 1.), ll.NewLatLong(3., 1.), ll.NewLatLong(3., 0.))
+
+	isTrue(t, u.Contains(ll.NewLatLong(2., .5)), "Left arm")
+	isTrue(t, u.Contains(ll.NewLatLong(2., 2.5)), "Right arm")
+	isTrue(t, u.Contains(ll.NewLatLong(.5, 1.5)), "Base")
+	isFalse(t, u.Contains(ll.NewLatLong(2., 1.5)), "Gap")
+}
+
+func TestContainsAcrossAntimeridian(t *testing.T) {
+
+	square := polygon.NewPolygon(ll.NewLatLong(-1., 179.), ll.NewLatLong(-1., -179.), ll.NewLatLong(1., -179.), ll.NewLatLong(1., 179.))
+
+	isTrue(t, square.Contains(ll.NewLatLong(.5, 179.5)), "East of antimeridian")
+	isTrue(t, square.Contains(ll.NewLatLong(-.5, -179.5)), "West of antimeridian")
+	isFalse(t, square.Contains(ll.NewLatLong(0., 0.)), "Prime meridian")
+	isFalse(t, square.Contains(ll.NewLatLong(0., 178.)), "West")
+
+	withinError(t, 4.*60.*60., square.Area().InSquareNauticalMiles(), 10., "Area")
+}
+
+func TestContainsPole(t *testing.T) {
+
+	vertices := []*ll.LatLong{ll.NewLatLong(80., 0.), ll.NewLatLong(80., 90.), ll.Normalized(80., 180.), ll.NewLatLong(80., -90.)}
+
+	for _, cap := range []*polygon.Polygon{polygon.NewPolygon(vertices...), polygon.NewPolygon(reversed(vertices)...)} {
+		isTrue(t, cap.Contains(ll.Normalized(90., 0.)), "North Pole")
+		isTrue(t, cap.Contains(ll.NewLatLong(85., 45.)), "Near Pole")
+		isFalse(t, cap.Contains(ll.NewLatLong(70., 45.)), "Outside")
+		isFalse(t, cap.Contains(ll.Normalized(-90., 0.)), "South Pole")
+		isFalse(t, cap.Contains(ll.NewLatLong(0., 0.)), "Equator")
+
+		// The edges bulge towards the pole so the area is bounded above by the cap above 80 degrees and below by the cap above the
+		// latitude of the edge midpoints
+		area := cap.Area().InSquareNauticalMiles()
+		r2 := sph.EarthRadiusNm * sph.EarthRadiusNm
+		isTrue(t, area < 2.*math.Pi*r2*(1.-math.Sin(80.*math.Pi/180.)), "Area upper bound")
+		isTrue(t, area > 2.*math.Pi*r2*(1.-math.Sin(82.9*math.Pi/180.)), "Area lower bound")
+	}
+}
@@ -0,0 +1,177 @@
+package projection
+
+import (
+	"math"
+	ll "stellarsunset/spherical/latlong"
+)
+
+// The polar stereographic projection, an azimuthal conformal projection centered on a pole with true scale there. Commonly used
+// for charts of the polar regions where the Mercator and conic projections break down.
+//
+// See Snyder, "Map Projections: A Working Manual" (1987) pp. 154-163.
+type PolarStereographic struct {
+	north           bool
+	centralMeridian float64
+}
+
+// Creates a stereographic projection centered on the North Pole, with the central meridian (in degrees) pointing down the chart
+// from it
+func NewNorthPolarStereographic(centralMeridian float64) *PolarStereographic {
+	return &PolarStereographic{true, centralMeridian}
+}
+
+// Creates a stereographic projection centered on the South Pole, with the central meridian (in degrees) pointing up the chart
+// from it
+func NewSouthPolarStereographic(centralMeridian float64) *PolarStereographic {
+	return &PolarStereographic{false, centralMeridian}
+}
+
+func (this *PolarStereographic) IsNorth() bool {
+	return this.north
+}
+
+func (this *PolarStereographic) CentralMeridian() float64 {
+	return this.centralMeridian
+}
+
+// Projects the LatLong, the opposite pole projects to infinity
+func (this *PolarStereographic) Forward(position *ll.LatLong) (x, y float64) {
+
+	// The distance from the pole, which in the south is measured with the latitude negated
+	rho := 2. * radius / halfTan(this.polarLatitude(position))
+	sinLambda, cosLambda := math.Sincos(relativeLongitude(position, this.centralMeridian))
+
+	if this.north {
+		return rho * sinLambda, -rho * cosLambda
+	}
+	return rho * sinLambda, rho * cosLambda
+}
+
+func (this *PolarStereographic) Inverse(x, y float64) *ll.LatLong {
+
+	if this.north {
+		y = -y
+	}
+	phi := math.Pi/2. - 2.*math.Atan(math.Hypot(x, y)/(2.*radius))
+	lambda := math.Atan2(x, y) + this.centralMeridian*degreesToRadians
+
+	if this.north {
+		return fromRadians(phi, lambda)
+	}
+	return fromRadians(-phi, lambda)
+}
+
+func (this *PolarStereographic) ScaleFactor(position *ll.LatLong) float64 {
+	return 2. / (1. + math.Sin(this.polarLatitude(position)))
+}
+
+// The latitude in radians, negated in the south so the projection's pole is always at pi/2
+func (this *PolarStereographic) polarLatitude(position *ll.LatLong) float64 {
+	if this.north {
+		return position.Latitude() * degreesToRadians
+	}
+	return -position.Latitude() * degreesToRadians
+}
+
+// The gnomonic projection, on which every great circle is a straight line, e.g. for planning great circle routes with a ruler.
+// Only the hemisphere around the center can be shown, and the scale grows quickly away from it.
+//
+// See Snyder, "Map Projections: A Working Manual" (1987) pp. 164-168.
+type Gnomonic struct {
+	center *ll.LatLong
+}
+
+func NewGnomonic(center *ll.LatLong) *Gnomonic {
+	return &Gnomonic{center}
+}
+
+func (this *Gnomonic) Center() *ll.LatLong {
+	return this.center
+}
+
+// Projects the LatLong, returning NaNs for positions 90 degrees or more from the center which have no projection
+func (this *Gnomonic) Forward(position *ll.LatLong) (x, y float64) {
+	u, v, cosC, _ := azimuthal(this.center, position)
+	if cosC <= 0. {
+		return math.NaN(), math.NaN()
+	}
+	return radius * u / cosC, radius * v / cosC
+}
+
+func (this *Gnomonic) Inverse(x, y float64) *ll.LatLong {
+	return azimuthalInverse(this.center, x, y, math.Atan(math.Hypot(x, y)/radius))
+}
+
+func (this *Gnomonic) ScaleFactor(position *ll.LatLong) float64 {
+	_, _, cosC, _ := azimuthal(this.center, position)
+	return 1. / cosC
+}
+
+// The azimuthal equidistant projection, on which both the distance and the course from the center to every other point are
+// true, e.g. for range rings around a radar. The whole world is shown, with the antipode of the center stretched around the edge.
+//
+// See Snyder, "Map Projections: A Working Manual" (1987) pp. 191-202.
+type AzimuthalEquidistant struct {
+	center *ll.LatLong
+}
+
+func NewAzimuthalEquidistant(center *ll.LatLong) *AzimuthalEquidistant {
+	return &AzimuthalEquidistant{center}
+}
+
+func (this *AzimuthalEquidistant) Center() *ll.LatLong {
+	return this.center
+}
+
+func (this *AzimuthalEquidistant) Forward(position *ll.LatLong) (x, y float64) {
+	u, v, _, c := azimuthal(this.center, position)
+	k := this.scale(c)
+	return radius * k * u, radius * k * v
+}
+
+func (this *AzimuthalEquidistant) Inverse(x, y float64) *ll.LatLong {
+	return azimuthalInverse(this.center, x, y, math.Hypot(x, y)/radius)
+}
+
+func (this *AzimuthalEquidistant) ScaleFactor(position *ll.LatLong) float64 {
+	_, _, _, c := azimuthal(this.center, position)
+	return this.scale(c)
+}
+
+// c / sin(c), tending to one at the center
+func (this *AzimuthalEquidistant) scale(c float64) float64 {
+	if c < 1e-9 {
+		return 1.
+	}
+	return c / math.Sin(c)
+}
+
+// Computes the east and north components of the direction to the position as seen from the center (each scaled by the sine of the
+// angular distance between them) along with the cosine of that angular distance and the distance itself in radians.
+func azimuthal(center, position *ll.LatLong) (u, v, cosC, c float64) {
+
+	sinPhi1, cosPhi1 := math.Sincos(center.Latitude() * degreesToRadians)
+	sinPhi, cosPhi := math.Sincos(position.Latitude() * degreesToRadians)
+	sinLambda, cosLambda := math.Sincos(relativeLongitude(position, center.Longitude()))
+
+	u = cosPhi * sinLambda
+	v = cosPhi1*sinPhi - sinPhi1*cosPhi*cosLambda
+	cosC = sinPhi1*sinPhi + cosPhi1*cosPhi*cosLambda
+	return u, v, cosC, math.Atan2(math.Hypot(u, v), cosC)
+}
+
+// Inverts the azimuthal projections given the angular distance (in radians) from the center of the chart coordinates
+func azimuthalInverse(center *ll.LatLong, x, y, c float64) *ll.LatLong {
+
+	rho := math.Hypot(x, y)
+	if rho == 0. {
+		return center
+	}
+
+	sinPhi1, cosPhi1 := math.Sincos(center.Latitude() * degreesToRadians)
+	sinC, cosC := math.Sincos(c)
+
+	phi := math.Asin(math.Max(-1., math.Min(1., cosC*sinPhi1+y*sinC*cosPhi1/rho)))
+	lambda := math.Atan2(x*sinC, rho*cosPhi1*cosC-y*sinPhi1*sinC)
+	return fromRadians(phi, lambda+center.Longitude()*degreesToRadians)
+}
@@ -0,0 +1,78 @@
+package projection_test
+
+import (
+	"math"
+	ll "stellarsunset/spherical/latlong"
+	"stellarsunset/spherical/projection"
+	"testing"
+)
+
+func TestPolarStereographic(t *testing.T) {
+
+	north := projection.NewNorthPolarStereographic(0.)
+
+	// The central meridian points down the chart from the pole and 90E to the right
+	x, yn := north.Forward(ll.NewLatLong(60., 0.))
+	withinError(t, 0., x, 1e-9, "X")
+	isTrue(t, yn < 0., "Down")
+
+	x, y := north.Forward(ll.NewLatLong(60., 90.))
+	withinError(t, 0., y, 1e-9, "Y")
+	isTrue(t, x > 0., "Right")
+
+	withinError(t, 1., north.ScaleFactor(ll.Normalized(90., 0.)), 1e-12, "Pole")
+	withinError(t, 2./(1.+math.Sqrt(3.)/2.), north.ScaleFactor(ll.NewLatLong(60., 45.)), 1e-12, "Scale")
+
+	// The south is the mirror image with the central meridian pointing up
+	south := projection.NewSouthPolarStereographic(0.)
+	_, ys := south.Forward(ll.NewLatLong(-60., 0.))
+	withinError(t, -yn, ys, 1e-9, "South")
+
+	position := south.Inverse(0., 0.)
+	withinError(t, -90., position.Latitude(), 1e-12, "SouthPole")
+}
+
+func TestGnomonicGreatCirclesAreStraight(t *testing.T) {
+
+	gnomonic := projection.NewGnomonic(ll.NewLatLong(45., -40.))
+	start, end := ll.NewLatLong(40.64, -73.78), ll.NewLatLong(51.47, -0.45)
+
+	x1, y1 := gnomonic.Forward(start)
+	x2, y2 := gnomonic.Forward(end)
+	length := math.Hypot(x2-x1, y2-y1)
+
+	for f := 0.1; f < 1.; f += 0.1 {
+		x, y := gnomonic.Forward(start.InterpolateTo(end, f))
+		// Distance from the line through the projected endpoints
+		withinError(t, 0., ((x2-x1)*(y1-y)-(x1-x)*(y2-y1))/length, 1e-6, "Straight")
+	}
+
+	// Only the hemisphere around the center can be projected
+	x, y := gnomonic.Forward(ll.NewLatLong(-45., 140.))
+	isTrue(t, math.IsNaN(x) && math.IsNaN(y), "Antipode")
+
+	withinError(t, 1., gnomonic.ScaleFactor(ll.NewLatLong(45., -40.)), 1e-12, "Center")
+}
+
+func TestAzimuthalEquidistantDistancesAreTrue(t *testing.T) {
+
+	center := ll.NewLatLong(52.31, 4.76)
+	equidistant := projection.NewAzimuthalEquidistant(center)
+
+	for _, position := range []*ll.LatLong{
+		ll.NewLatLong(52.4, 4.8),
+		ll.NewLatLong(40.64, -73.78),
+		ll.NewLatLong(-33.95, 151.18),
+		ll.NewLatLong(-52., -175.),
+	} {
+		x, y := equidistant.Forward(position)
+		withinError(t, center.DistanceInNm(position), math.Hypot(x, y), 1e-6, "Distance")
+
+		course := math.Mod(math.Atan2(x, y)*180./math.Pi+360., 360.)
+		withinError(t, center.CourseInDegrees(position), course, 1e-6, "Course")
+	}
+
+	x, y := equidistant.Forward(center)
+	withinError(t, 0., math.Hypot(x, y), 1e-9, "Center")
+	withinError(t, 1., equidistant.ScaleFactor(center), 1e-12, "Scale")
+}
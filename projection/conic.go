@@ -0,0 +1,94 @@
+package projection
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	ll "stellarsunset/spherical/latlong"
+)
+
+var ErrInvalidParallels = errors.New("Invalid standard parallels")
+
+// The Lambert conformal conic projection with two standard parallels, along which the scale is true. Widely used for aeronautical
+// charts of the mid-latitudes where the scale varies by less than a percent between and slightly beyond the parallels.
+//
+// See Snyder, "Map Projections: A Working Manual" (1987) pp. 104-110.
+type LambertConformalConic struct {
+	origin *ll.LatLong
+	// The cone constant, scale constant and the radius of the origin's parallel on the chart
+	n, f, rho0 float64
+}
+
+// Creates a Lambert conformal conic projection with the two standard parallels (in degrees) and the origin of the chart
+// coordinates, panicking if the parallels are invalid. The parallels may be equal for a single standard parallel.
+func NewLambertConformalConic(origin *ll.LatLong, standard1, standard2 float64) *LambertConformalConic {
+	p, err := TryNewLambertConformalConic(origin, standard1, standard2)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// Creates a Lambert conformal conic projection with the two standard parallels (in degrees) and the origin of the chart
+// coordinates, returning an error matching ErrInvalidParallels if either parallel is at or beyond a pole or they are
+// symmetric about the equator (in which case the cone is a cylinder, see Mercator).
+func TryNewLambertConformalConic(origin *ll.LatLong, standard1, standard2 float64) (*LambertConformalConic, error) {
+
+	if math.Abs(standard1) >= 90. || math.Abs(standard2) >= 90. {
+		return nil, fmt.Errorf("%w: %f and %f must be within (-90, 90)", ErrInvalidParallels, standard1, standard2)
+	}
+	if math.Abs(standard1+standard2) < 1e-9 {
+		return nil, fmt.Errorf("%w: %f and %f are symmetric about the equator", ErrInvalidParallels, standard1, standard2)
+	}
+
+	phi1, phi2 := standard1*degreesToRadians, standard2*degreesToRadians
+
+	n := math.Sin(phi1)
+	if math.Abs(phi1-phi2) > 1e-12 {
+		n = math.Log(math.Cos(phi1)/math.Cos(phi2)) / math.Log(halfTan(phi2)/halfTan(phi1))
+	}
+	f := math.Cos(phi1) * math.Pow(halfTan(phi1), n) / n
+
+	this := &LambertConformalConic{origin: origin, n: n, f: f}
+	this.rho0 = this.rho(origin.Latitude() * degreesToRadians)
+	return this, nil
+}
+
+func (this *LambertConformalConic) Origin() *ll.LatLong {
+	return this.origin
+}
+
+// Projects the LatLong, the pole the cone opens towards projects to infinity
+func (this *LambertConformalConic) Forward(position *ll.LatLong) (x, y float64) {
+	rho := this.rho(position.Latitude() * degreesToRadians)
+	theta := this.n * relativeLongitude(position, this.origin.Longitude())
+	return rho * math.Sin(theta), this.rho0 - rho*math.Cos(theta)
+}
+
+func (this *LambertConformalConic) Inverse(x, y float64) *ll.LatLong {
+
+	sign := math.Copysign(1., this.n)
+	rho := sign * math.Hypot(x, this.rho0-y)
+	theta := math.Atan2(sign*x, sign*(this.rho0-y))
+
+	phi := sign * math.Pi / 2.
+	if rho != 0. {
+		phi = 2.*math.Atan(math.Pow(radius*this.f/rho, 1./this.n)) - math.Pi/2.
+	}
+	return fromRadians(phi, theta/this.n+this.origin.Longitude()*degreesToRadians)
+}
+
+func (this *LambertConformalConic) ScaleFactor(position *ll.LatLong) float64 {
+	phi := position.Latitude() * degreesToRadians
+	return this.rho(phi) * this.n / (radius * math.Cos(phi))
+}
+
+// The radius of the latitude's parallel on the chart
+func (this *LambertConformalConic) rho(phi float64) float64 {
+	return radius * this.f / math.Pow(halfTan(phi), this.n)
+}
+
+// tan(pi/4 + phi/2), which appears throughout the conformal projections
+func halfTan(phi float64) float64 {
+	return math.Tan(math.Pi/4. + phi/2.)
+}
@@ -0,0 +1,47 @@
+package projection_test
+
+import (
+	"errors"
+	"math"
+	ll "stellarsunset/spherical/latlong"
+	"stellarsunset/spherical/projection"
+	"testing"
+)
+
+func TestLambertStandardParallels(t *testing.T) {
+
+	lambert := projection.NewLambertConformalConic(ll.NewLatLong(23., -96.), 29.5, 45.5)
+
+	// Scale is true along the standard parallels, too small between them and too large outside
+	withinError(t, 1., lambert.ScaleFactor(ll.NewLatLong(29.5, -120.)), 1e-12, "Lower")
+	withinError(t, 1., lambert.ScaleFactor(ll.NewLatLong(45.5, -70.)), 1e-12, "Upper")
+	isTrue(t, lambert.ScaleFactor(ll.NewLatLong(37.5, -96.)) < 1., "Between")
+	isTrue(t, lambert.ScaleFactor(ll.NewLatLong(60., -96.)) > 1., "Outside")
+
+	x, y := lambert.Forward(ll.NewLatLong(23., -96.))
+	withinError(t, 0., x, 1e-9, "X")
+	withinError(t, 0., y, 1e-9, "Y")
+
+	// Distances along the standard parallel are true over short ranges
+	x1, y1 := lambert.Forward(ll.NewLatLong(45.5, -96.))
+	x2, y2 := lambert.Forward(ll.NewLatLong(45.5, -95.9))
+	withinError(t, ll.NewLatLong(45.5, -96.).DistanceInNm(ll.NewLatLong(45.5, -95.9)), math.Hypot(x2-x1, y2-y1), 1e-4, "Distance")
+}
+
+func TestLambertSingleParallel(t *testing.T) {
+
+	lambert := projection.NewLambertConformalConic(ll.NewLatLong(50., 10.), 50., 50.)
+
+	withinError(t, 1., lambert.ScaleFactor(ll.NewLatLong(50., 0.)), 1e-12, "Parallel")
+	isTrue(t, lambert.ScaleFactor(ll.NewLatLong(40., 0.)) > 1., "South")
+	isTrue(t, lambert.ScaleFactor(ll.NewLatLong(60., 0.)) > 1., "North")
+}
+
+func TestLambertInvalidParallels(t *testing.T) {
+
+	_, err := projection.TryNewLambertConformalConic(ll.NewLatLong(0., 0.), 30., -30.)
+	isTrue(t, errors.Is(err, projection.ErrInvalidParallels), "Symmetric")
+
+	_, err = projection.TryNewLambertConformalConic(ll.NewLatLong(0., 0.), 30., 90.)
+	isTrue(t, errors.Is(err, projection.ErrInvalidParallels), "Pole")
+}
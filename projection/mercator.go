@@ -0,0 +1,47 @@
+package projection
+
+import (
+	"math"
+	ll "stellarsunset/spherical/latlong"
+)
+
+// Latitude limit of Web Mercator, at which the map is a square
+const webMercatorLimit float64 = 85.0511287798066
+
+// The Mercator projection, a cylindrical conformal projection on which rhumb lines are straight. The scale grows without limit
+// towards the poles, which project to infinity.
+type Mercator struct {
+	centralMeridian float64
+	// The latitude beyond which positions are clamped, or 90 for none
+	limit float64
+}
+
+// Creates a Mercator projection centered on the meridian (in degrees), with x zero along it and y zero along the equator
+func NewMercator(centralMeridian float64) *Mercator {
+	return &Mercator{centralMeridian, 90.}
+}
+
+// Creates a Mercator projection matching the Web Mercator used by online slippy maps, which is clamped to latitudes within
+// 85.05 degrees so the world is square. Multiplying the coordinates by the ratio of 6378137m to EarthRadiusNm gives the
+// EPSG:3857 coordinates in meters.
+func NewWebMercator() *Mercator {
+	return &Mercator{0., webMercatorLimit}
+}
+
+func (this *Mercator) CentralMeridian() float64 {
+	return this.centralMeridian
+}
+
+// Projects the LatLong, positions beyond a Web Mercator's limits are clamped to them
+func (this *Mercator) Forward(position *ll.LatLong) (x, y float64) {
+	phi := math.Max(-this.limit, math.Min(this.limit, position.Latitude())) * degreesToRadians
+	return radius * relativeLongitude(position, this.centralMeridian), radius * math.Asinh(math.Tan(phi))
+}
+
+func (this *Mercator) Inverse(x, y float64) *ll.LatLong {
+	return fromRadians(math.Atan(math.Sinh(y/radius)), x/radius+this.centralMeridian*degreesToRadians)
+}
+
+func (this *Mercator) ScaleFactor(position *ll.LatLong) float64 {
+	return 1. / math.Cos(position.Latitude()*degreesToRadians)
+}
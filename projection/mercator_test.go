@@ -0,0 +1,50 @@
+package projection_test
+
+import (
+	"math"
+	ll "stellarsunset/spherical/latlong"
+	"stellarsunset/spherical/projection"
+	"testing"
+)
+
+func TestMercatorRhumbLinesAreStraight(t *testing.T) {
+
+	mercator := projection.NewMercator(0.)
+	start := ll.NewLatLong(10., -20.)
+
+	// Points along a rhumb line all lie on the line through the start in the direction of its course
+	x0, y0 := mercator.Forward(start)
+	for nm := 100.; nm <= 2000.; nm += 100. {
+		x, y := mercator.Forward(start.RhumbProjectOut(35., nm))
+		withinError(t, 35., math.Atan2(x-x0, y-y0)*180./math.Pi, 1e-9, "Course")
+	}
+}
+
+func TestMercator(t *testing.T) {
+
+	mercator := projection.NewMercator(-90.)
+
+	x, y := mercator.Forward(ll.NewLatLong(0., -89.))
+	withinError(t, 60.00687, x, 1e-5, "X")
+	withinError(t, 0., y, 1e-9, "Y")
+
+	// Longitudes wrap around the back of the central meridian
+	x, _ = mercator.Forward(ll.NewLatLong(0., 91.))
+	withinError(t, -60.00687*179., x, 1e-3, "Wrapped")
+
+	withinError(t, 2., mercator.ScaleFactor(ll.NewLatLong(60., 0.)), 1e-12, "Scale")
+}
+
+func TestWebMercator(t *testing.T) {
+
+	web := projection.NewWebMercator()
+	toMeters := 6378137. / 3438.14021579022
+
+	// The limit makes the world square, with the corner of the top left tile at (-20037508.34, 20037508.34)
+	x, y := web.Forward(ll.Normalized(90., -180.))
+	withinError(t, -20037508.34, x*toMeters, 1e-2, "X")
+	withinError(t, 20037508.34, y*toMeters, 1e-2, "Y")
+
+	position := web.Inverse(0., 20037508.34/toMeters)
+	withinError(t, 85.0511287798, position.Latitude(), 1e-7, "Latitude")
+}
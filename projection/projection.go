@@ -0,0 +1,50 @@
+/*
+This Projection package maps LatLongs onto flat charts and back, e.g. for rendering traffic, with the common map projections used
+in aeronautical and nautical charting.
+
+All projections are of the sphere used throughout this library (radius EarthRadiusNm) and return x (east) and y (north)
+coordinates in nautical miles, so distances measured on a chart close to where its scale factor is one match DistanceTo.
+*/
+package projection
+
+import (
+	"math"
+	sph "stellarsunset/spherical"
+	ll "stellarsunset/spherical/latlong"
+)
+
+const (
+	radius float64 = sph.EarthRadiusNm
+
+	degreesToRadians float64 = math.Pi / 180.
+	radiansToDegrees float64 = 180. / math.Pi
+)
+
+// A Projection maps LatLongs to x (east) and y (north) coordinates in nautical miles on a flat chart and back
+type Projection interface {
+	// The chart coordinates of the LatLong
+	Forward(position *ll.LatLong) (x, y float64)
+	// The LatLong at the chart coordinates
+	Inverse(x, y float64) *ll.LatLong
+	// The ratio of distances on the chart to distances on the Earth close to the LatLong. For conformal projections this is the
+	// same in all directions, for the azimuthal ones it's the scale perpendicular to the line from the center.
+	ScaleFactor(position *ll.LatLong) float64
+}
+
+var (
+	_ Projection = (*Mercator)(nil)
+	_ Projection = (*LambertConformalConic)(nil)
+	_ Projection = (*PolarStereographic)(nil)
+	_ Projection = (*Gnomonic)(nil)
+	_ Projection = (*AzimuthalEquidistant)(nil)
+)
+
+// The longitude in radians of the position relative to the meridian (in degrees), in the range [-pi, pi)
+func relativeLongitude(position *ll.LatLong, meridian float64) float64 {
+	return math.Remainder(position.Longitude()-meridian, 360.) * degreesToRadians
+}
+
+// Converts latitude and longitude in radians back to a LatLong, wrapping the longitude into range
+func fromRadians(phi, lambda float64) *ll.LatLong {
+	return ll.Normalized(phi*radiansToDegrees, math.Remainder(lambda*radiansToDegrees, 360.))
+}
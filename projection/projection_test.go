@@ -0,0 +1,52 @@
+package projection_test
+
+import (
+	"math"
+	ll "stellarsunset/spherical/latlong"
+	"stellarsunset/spherical/projection"
+	"testing"
+)
+
+func isTrue(t *testing.T, condition bool, s string) {
+	if !condition {
+		t.Error(s)
+	}
+}
+
+func withinError(t *testing.T, expected, actual, tolerance float64, s string) {
+	if math.Abs(expected-actual) > tolerance {
+		t.Errorf("%s: want = %f, got = %f, tol = %f", s, expected, actual, tolerance)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+
+	projections := map[string]projection.Projection{
+		"Mercator":      projection.NewMercator(-30.),
+		"WebMercator":   projection.NewWebMercator(),
+		"Lambert":       projection.NewLambertConformalConic(ll.NewLatLong(40., -96.), 33., 45.),
+		"LambertSouth":  projection.NewLambertConformalConic(ll.NewLatLong(-32., 135.), -18., -36.),
+		"NorthPolar":    projection.NewNorthPolarStereographic(-45.),
+		"SouthPolar":    projection.NewSouthPolarStereographic(0.),
+		"Gnomonic":      projection.NewGnomonic(ll.NewLatLong(51.5, -0.1)),
+		"Equidistant":   projection.NewAzimuthalEquidistant(ll.NewLatLong(-33.9, 151.2)),
+		"EquidistantNP": projection.NewAzimuthalEquidistant(ll.NewLatLong(89.9, 0.)),
+	}
+
+	for name, p := range projections {
+		for lat := -80.; lat <= 80.; lat += 10. {
+			for lon := -170.; lon < 180.; lon += 20. {
+				position := ll.NewLatLong(lat, lon)
+
+				x, y := p.Forward(position)
+				if math.IsNaN(x) {
+					// Positions beyond the gnomonic's horizon have no projection
+					continue
+				}
+
+				returned := p.Inverse(x, y)
+				withinError(t, 0., position.DistanceInNm(returned), 1e-6, name)
+			}
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package spherical
+
+import (
+	"math"
+)
+
+// Tolerance on the difference in projected latitude below which a rhumb line is treated as running due east/west
+const rhumbTolerance float64 = 1e-12
+
+// Compute the Rhumb Line (loxodrome) distance between two (latitude, longitude) coordinates in nautical miles, this is the length
+// of the path that holds a constant course between them and is always at least as long as the Great Circle distance.
+func RhumbDistanceInNm(lat1, lon1, lat2, lon2 float64) float64 {
+
+	latRad1, latRad2 := toRadians(lat1), toRadians(lat2)
+
+	dLat := latRad2 - latRad1
+	dLon := rhumbLongitudeDifference(lon1, lon2)
+
+	// Longitude is meaningless at the poles, every Rhumb Line reaching one arrives after travelling the change in latitude
+	if math.Abs(lat1) == 90. || math.Abs(lat2) == 90. {
+		dLon = 0.
+	}
+
+	return EarthRadiusNm * math.Hypot(dLat, rhumbStretch(latRad1, latRad2)*dLon)
+}
+
+// Compute the constant Rhumb Line (loxodrome) course between two (latitude, longitude) coordinates in degrees [0, 360)
+func RhumbCourseInDegrees(startLat, startLon, endLat, endLon float64) float64 {
+
+	dPsi := projectedLatitudeDifference(toRadians(startLat), toRadians(endLat))
+	dLon := rhumbLongitudeDifference(startLon, endLon)
+
+	return mod(toDegrees(math.Atan2(dLon, dPsi)), 360.)
+}
+
+// Compute a new (latitude, longitude) location by travelling the provided distance in NM from the starting location while holding
+// the provided course constant.
+//
+// Due north and south paths reaching a pole continue down the opposite side of it along the meridian, passing over the poles as
+// many times as the distance requires. Every other rhumb line
+// spirals into the pole without ever crossing it, so longer distances stop at the pole (with the starting longitude, as any is
+// valid there), see RhumbProjectOutE to detect this.
+func RhumbProjectOut(lat, lon, headingDegrees, distanceNm float64) (latitude, longitude float64) {
+
+	if math.IsNaN(headingDegrees) {
+		panic("Heading cannot be NaN")
+	}
+	if math.IsNaN(distanceNm) {
+		panic("Distance cannot be NaN")
+	}
+	latitude, longitude, _ = rhumbProjectOut(lat, lon, headingDegrees, distanceNm)
+	return latitude, longitude
+}
+
+// Projects along the rhumb line as RhumbProjectOut does, also returning whether a non-meridional path was stopped at a pole
+func rhumbProjectOut(lat, lon, headingDegrees, distanceNm float64) (latitude, longitude float64, pastPole bool) {
+
+	course, dist := headingDegrees, math.Abs(distanceNm)/EarthRadiusNm
+	if distanceNm < 0. {
+		course = mod(headingDegrees+180., 360.)
+	}
+	course = toRadians(course)
+
+	latRad, lonRad := toRadians(lat), toRadians(lon)
+	latProj := latRad + dist*math.Cos(course)
+
+	if math.Abs(latProj) > math.Pi/2. {
+		// Rhumb lines other than meridians spiral into the pole, circling it infinitely many times, so stop there
+		if math.Abs(math.Sin(course)) > rhumbTolerance {
+			return math.Copysign(90., latProj), mod(lon+180., 360.) - 180., true
+		}
+		// Meridians continue around the great circle through both poles, which in [-90, 270) degrees of travel from the equator
+		// is on the starting longitude up to 90 and on the longitude 180 degrees away beyond it
+		around := mod(latProj+math.Pi/2., 2.*math.Pi) - math.Pi/2.
+		if around > math.Pi/2. {
+			return toDegrees(math.Pi - around), mod(lon, 360.) - 180., false
+		}
+		return toDegrees(around), mod(lon+180., 360.) - 180., false
+	}
+
+	dLon := dist * math.Sin(course) / rhumbStretch(latRad, latProj)
+	lonProj := lonRad + dLon
+
+	return toDegrees(latProj), mod(toDegrees(lonProj)+180., 360.) - 180., false
+}
+
+// The difference in Mercator projected latitude between two latitudes in radians
+func projectedLatitudeDifference(latRad1, latRad2 float64) float64 {
+	return math.Log(math.Tan(math.Pi/4.+latRad2/2.) / math.Tan(math.Pi/4.+latRad1/2.))
+}
+
+// The ratio of the change in latitude to the change in projected latitude, i.e. the cosine of the latitude for east/west lines
+func rhumbStretch(latRad1, latRad2 float64) float64 {
+	dPsi := projectedLatitudeDifference(latRad1, latRad2)
+	if math.Abs(dPsi) > rhumbTolerance {
+		return (latRad2 - latRad1) / dPsi
+	}
+	return math.Cos(latRad1)
+}
+
+// The shortest longitude difference (in radians) between two longitudes in degrees, crossing the antimeridian when shorter
+func rhumbLongitudeDifference(lon1, lon2 float64) float64 {
+	return toRadians(modAngleDifference(mod(lon2-lon1, 360.)))
+}
@@ -0,0 +1,150 @@
+package spherical_test
+
+import (
+	"math"
+	sph "stellarsunset/spherical"
+	"testing"
+)
+
+func TestRhumbDistanceInNm(t *testing.T) {
+
+	// Dover to Calais, http://www.movable-type.co.uk/scripts/latlong.html
+	expectedDistanceKm := 40.31
+	kmPerNm := 1.852
+	withinError(t, expectedDistanceKm/kmPerNm, sph.RhumbDistanceInNm(51.127, 1.338, 50.964, 1.853), .05, "RhumbDistanceInNm()")
+}
+
+func TestRhumbDistanceAtLeastGreatCircle(t *testing.T) {
+
+	rhumb := sph.RhumbDistanceInNm(40.7128, -74.0060, 51.5074, -.1278)
+	greatCircle := sph.DistanceInNm(40.7128, -74.0060, 51.5074, -.1278)
+
+	if rhumb < greatCircle {
+		t.Errorf("Rhumb distance %f should not be shorter than great circle distance %f", rhumb, greatCircle)
+	}
+}
+
+func TestRhumbDistanceAlongMeridianAndEquator(t *testing.T) {
+
+	withinError(t, sph.DistanceInNm(0., 0., 10., 0.), sph.RhumbDistanceInNm(0., 0., 10., 0.), 1e-9, "Meridian")
+	withinError(t, sph.DistanceInNm(0., 0., 0., 10.), sph.RhumbDistanceInNm(0., 0., 0., 10.), 1e-9, "Equator")
+}
+
+func TestRhumbDistanceAlongParallel(t *testing.T) {
+
+	// Along a parallel a rhumb line is shortened by the cosine of the latitude
+	withinError(t, .5*sph.RhumbDistanceInNm(0., 0., 0., 10.), sph.RhumbDistanceInNm(60., 0., 60., 10.), 1e-9, "Parallel")
+}
+
+func TestRhumbDistanceAcrossAntimeridian(t *testing.T) {
+
+	withinError(t, sph.RhumbDistanceInNm(0., -5., 0., 5.), sph.RhumbDistanceInNm(0., 175., 0., -175.), 1e-9, "Antimeridian")
+}
+
+func TestRhumbDistanceToPole(t *testing.T) {
+
+	withinError(t, sph.DistanceInNm(80., 10., 90., 0.), sph.RhumbDistanceInNm(80., 10., 90., 0.), 1e-9, "Pole")
+}
+
+func TestRhumbCourseInDegrees(t *testing.T) {
+
+	// Dover to Calais, http://www.movable-type.co.uk/scripts/latlong.html
+	withinError(t, 116.7, sph.RhumbCourseInDegrees(51.127, 1.338, 50.964, 1.853), .1, "RhumbCourseInDegrees()")
+}
+
+func TestRhumbCourseCardinals(t *testing.T) {
+
+	withinError(t, 0., sph.RhumbCourseInDegrees(0., 0., 10., 0.), 1e-9, "North")
+	withinError(t, 90., sph.RhumbCourseInDegrees(0., 0., 0., 10.), 1e-9, "East")
+	withinError(t, 180., sph.RhumbCourseInDegrees(10., 0., 0., 0.), 1e-9, "South")
+	withinError(t, 270., sph.RhumbCourseInDegrees(0., 10., 0., 0.), 1e-9, "West")
+}
+
+func TestRhumbCourseAcrossAntimeridian(t *testing.T) {
+
+	withinError(t, 90., sph.RhumbCourseInDegrees(10., 175., 10., -175.), 1e-9, "East")
+	withinError(t, 270., sph.RhumbCourseInDegrees(10., -175., 10., 175.), 1e-9, "West")
+}
+
+func TestRhumbProjectOut(t *testing.T) {
+
+	startLat, startLon := 51.127, 1.338
+	expectedLat, expectedLon := 50.964, 1.853
+
+	course := sph.RhumbCourseInDegrees(startLat, startLon, expectedLat, expectedLon)
+	distance := sph.RhumbDistanceInNm(startLat, startLon, expectedLat, expectedLon)
+
+	actualLat, actualLon := sph.RhumbProjectOut(startLat, startLon, course, distance)
+
+	withinError(t, expectedLat, actualLat, 1e-9, "Latitude")
+	withinError(t, expectedLon, actualLon, 1e-9, "Longitude")
+}
+
+func TestRhumbProjectOutHoldsCourse(t *testing.T) {
+
+	lat, lon := sph.RhumbProjectOut(0., 0., 45., 3000.)
+
+	withinError(t, 45., sph.RhumbCourseInDegrees(0., 0., lat, lon), 1e-9, "Course")
+	withinError(t, 3000., sph.RhumbDistanceInNm(0., 0., lat, lon), 1e-6, "Distance")
+}
+
+func TestRhumbProjectOutNegativeDistance(t *testing.T) {
+
+	lat, lon := sph.RhumbProjectOut(0., 0., 90., -60.)
+
+	withinError(t, 0., lat, 1e-9, "Latitude")
+	withinError(t, -(60./sph.EarthRadiusNm)*180./math.Pi, lon, 1e-9, "Longitude")
+}
+
+func TestRhumbProjectOutAcrossAntimeridian(t *testing.T) {
+
+	lat, lon := sph.RhumbProjectOut(0., 179., 90., sph.RhumbDistanceInNm(0., 0., 0., 2.))
+
+	withinError(t, 0., lat, 1e-9, "Latitude")
+	withinError(t, -179., lon, 1e-9, "Longitude")
+}
+
+func TestRhumbProjectOutOverPole(t *testing.T) {
+
+	lat, lon := sph.RhumbProjectOut(89., 0., 0., sph.DistanceInNm(89., 0., 88., 180.))
+
+	withinError(t, 88., lat, 1e-9, "Latitude")
+	withinError(t, -180., lon, 1e-9, "Longitude")
+}
+
+func TestRhumbProjectOutAroundMeridian(t *testing.T) {
+
+	// A circumference and 29.95 degrees more due north from 80N passes over the north pole, the south pole and the north pole again
+	circumference := 2. * math.Pi * sph.EarthRadiusNm
+	lat, lon := sph.RhumbProjectOut(80., 0., 0., circumference+sph.DistanceInNm(0., 0., 39.95, 0.))
+
+	withinError(t, 60.05, lat, 1e-9, "Latitude")
+	withinError(t, -180., lon, 1e-9, "Longitude")
+
+	// Two circumferences due south return to the start
+	lat, lon = sph.RhumbProjectOut(-30., 45., 180., 2.*circumference)
+	withinError(t, -30., lat, 1e-9, "Latitude")
+	withinError(t, 45., lon, 1e-9, "Longitude")
+
+	// And one and a half reach the same latitude in the other hemisphere on the opposite meridian
+	lat, lon = sph.RhumbProjectOut(-30., 45., 180., 1.5*circumference)
+	withinError(t, 30., lat, 1e-9, "Latitude")
+	withinError(t, -135., lon, 1e-9, "Longitude")
+}
+
+func TestRhumbProjectOutIntoPole(t *testing.T) {
+
+	// Off a meridian the path spirals into the pole and stops there
+	lat, lon := sph.RhumbProjectOut(89., 10., 45., 200.)
+
+	withinError(t, 90., lat, 1e-9, "Latitude")
+	withinError(t, 10., lon, 1e-9, "Longitude")
+
+	lat, _ = sph.RhumbProjectOut(-89., 10., 80., -1000.)
+	withinError(t, -90., lat, 1e-9, "Latitude")
+
+	// Due south over the south pole continues up the opposite meridian
+	lat, lon = sph.RhumbProjectOut(-89., 10., 180., sph.DistanceInNm(-89., 0., -89., 180.))
+	withinError(t, -89., lat, 1e-9, "Latitude")
+	withinError(t, -170., lon, 1e-9, "Longitude")
+}
@@ -0,0 +1,81 @@
+package spherical
+
+import (
+	"fmt"
+	"math"
+)
+
+// Describes where along a Great Circle segment the closest point to some position falls
+type SegmentLocation int
+
+const (
+	// The closest point is the start of the segment, i.e. the position is behind it
+	SegmentStart SegmentLocation = iota
+	// The closest point lies strictly between the start and end of the segment
+	SegmentInterior
+	// The closest point is the end of the segment, i.e. the position is beyond it
+	SegmentEnd
+)
+
+var segmentLocations = [...]string{
+	SegmentStart:    "Start",
+	SegmentInterior: "Interior",
+	SegmentEnd:      "End",
+}
+
+func (this SegmentLocation) String() string {
+	return segmentLocations[this]
+}
+
+// Compute the point on the Great Circle segment between the start and end location that is closest to the provided position,
+// along with the distance (in nautical miles) from the position to it and where along the segment it falls.
+//
+// Unlike CrossTrackDistanceNm the returned distance accounts for the extents of the segment, positions beyond either end are
+// measured to that endpoint.
+func ClosestPointOnSegment(startLat, startLon, endLat, endLon, posLat, posLon float64) (latitude, longitude, distanceNm float64, location SegmentLocation) {
+	latitude, longitude, distanceNm, location, err := closestPointOnSegment(startLat, startLon, endLat, endLon, posLat, posLon)
+	if err != nil {
+		panic(err)
+	}
+	return latitude, longitude, distanceNm, location
+}
+
+func closestPointOnSegment(startLat, startLon, endLat, endLon, posLat, posLon float64) (latitude, longitude, distanceNm float64, location SegmentLocation, err error) {
+
+	length := DistanceInNm(startLat, startLon, endLat, endLon)
+	if length == 0. {
+		return startLat, startLon, DistanceInNm(startLat, startLon, posLat, posLon), SegmentStart, nil
+	}
+
+	// Antipodal endpoints are joined by every meridian between them, so the segment's Great Circle is undefined
+	if math.Pi*EarthRadiusNm-length < tolerance*EarthRadiusNm {
+		err := fmt.Errorf("%w: segment endpoints are antipodal", ErrDegenerateTrack)
+		return math.NaN(), math.NaN(), math.NaN(), SegmentStart, err
+	}
+
+	ctd := CrossTrackDistanceNm(startLat, startLon, endLat, endLon, posLat, posLon)
+	atd, err := alongTrackDistanceNmE(startLat, startLon, endLat, endLon, posLat, posLon, ctd)
+
+	if err != nil {
+		return math.NaN(), math.NaN(), math.NaN(), SegmentStart, err
+	}
+	if 0. < atd && atd < length {
+		latitude, longitude = ProjectOut(startLat, startLon, CourseInDegrees(startLat, startLon, endLat, endLon), atd)
+		return latitude, longitude, math.Abs(ctd), SegmentInterior, nil
+	}
+
+	// Otherwise the closest point is an endpoint, which isn't necessarily the one on the same side as the position when it's
+	// far around the Great Circle
+	toStart, toEnd := DistanceInNm(startLat, startLon, posLat, posLon), DistanceInNm(endLat, endLon, posLat, posLon)
+	if toStart <= toEnd {
+		return startLat, startLon, toStart, SegmentStart, nil
+	}
+	return endLat, endLon, toEnd, SegmentEnd, nil
+}
+
+// Compute the distance (in nautical miles) between the provided position and the closest point to it on the Great Circle segment
+// between the start and end location.
+func DistanceToSegmentNm(startLat, startLon, endLat, endLon, posLat, posLon float64) float64 {
+	_, _, distanceNm, _ := ClosestPointOnSegment(startLat, startLon, endLat, endLon, posLat, posLon)
+	return distanceNm
+}
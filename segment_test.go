@@ -0,0 +1,114 @@
+package spherical_test
+
+import (
+	"math"
+	sph "stellarsunset/spherical"
+	"testing"
+)
+
+func TestClosestPointOnSegmentInterior(t *testing.T) {
+
+	lat, lon, nm, location := sph.ClosestPointOnSegment(0., 0., 0., 10., 1., 5.)
+
+	withinError(t, 0., lat, 1e-9, "Latitude")
+	withinError(t, 5., lon, 1e-6, "Longitude")
+	withinError(t, sph.EarthRadiusNm*math.Pi/180., nm, 1e-9, "Distance")
+	withinError(t, math.Abs(sph.CrossTrackDistanceNm(0., 0., 0., 10., 1., 5.)), nm, 1e-4, "Cross track distance")
+
+	if location != sph.SegmentInterior {
+		t.Errorf("ClosestPointOnSegment(...) location = %s, want %s", location, sph.SegmentInterior)
+	}
+}
+
+func TestClosestPointOnSegmentBeforeStart(t *testing.T) {
+
+	lat, lon, nm, location := sph.ClosestPointOnSegment(0., 0., 0., 10., 1., -5.)
+
+	withinError(t, 0., lat, 1e-9, "Latitude")
+	withinError(t, 0., lon, 1e-9, "Longitude")
+	withinError(t, sph.DistanceInNm(0., 0., 1., -5.), nm, 1e-9, "Distance")
+
+	if location != sph.SegmentStart {
+		t.Errorf("ClosestPointOnSegment(...) location = %s, want %s", location, sph.SegmentStart)
+	}
+
+	// The distance to the infinite great circle is misleadingly small
+	if math.Abs(sph.CrossTrackDistanceNm(0., 0., 0., 10., 1., -5.)) >= nm {
+		t.Error("Expected segment distance to exceed the cross track distance")
+	}
+}
+
+func TestClosestPointOnSegmentBeyondEnd(t *testing.T) {
+
+	lat, lon, nm, location := sph.ClosestPointOnSegment(0., 0., 0., 10., -1., 15.)
+
+	withinError(t, 0., lat, 1e-9, "Latitude")
+	withinError(t, 10., lon, 1e-9, "Longitude")
+	withinError(t, sph.DistanceInNm(0., 10., -1., 15.), nm, 1e-9, "Distance")
+
+	if location != sph.SegmentEnd {
+		t.Errorf("ClosestPointOnSegment(...) location = %s, want %s", location, sph.SegmentEnd)
+	}
+}
+
+func TestClosestPointOnSegmentNearPoleOfGreatCircle(t *testing.T) {
+
+	// Close to the pole of the segment's great circle the along track distance is poorly conditioned, but the closest point is
+	// still the foot of the meridian through the position
+	lat, lon, nm, location := sph.ClosestPointOnSegment(0., 0., 0., 10., 89.99, 5.)
+
+	withinError(t, 0., lat, 1e-9, "Latitude")
+	withinError(t, 5., lon, 1e-5, "Longitude")
+	withinError(t, sph.EarthRadiusNm*89.99*math.Pi/180., nm, 1e-6, "Distance")
+	if nm >= sph.DistanceInNm(0., 10., 89.99, 5.) {
+		t.Error("Expected the interior point to be closer than the endpoints")
+	}
+
+	if location != sph.SegmentInterior {
+		t.Errorf("ClosestPointOnSegment(...) location = %s, want %s", location, sph.SegmentInterior)
+	}
+
+	// Exactly at the pole every point is equidistant so the start is returned
+	_, _, nm, location = sph.ClosestPointOnSegment(0., 0., 0., 10., 90., 5.)
+	withinError(t, sph.EarthRadiusNm*math.Pi/2., nm, 1e-9, "Distance")
+
+	if location != sph.SegmentStart {
+		t.Errorf("ClosestPointOnSegment(...) location = %s, want %s", location, sph.SegmentStart)
+	}
+}
+
+func TestAlongTrackDistanceNearPoleOfGreatCircle(t *testing.T) {
+
+	ahead := sph.CrossTrackDistanceNm(0., 0., 0., 10., 89.99, 5.)
+	withinError(t, -sph.EarthRadiusNm*89.99*math.Pi/180., ahead, 1e-6, "Cross track distance")
+	withinError(t, sph.EarthRadiusNm*5.*math.Pi/180., sph.AlongTrackDistanceNm(0., 0., 0., 10., 89.99, 5., ahead), 1e-3, "Ahead")
+
+	behind := sph.CrossTrackDistanceNm(0., 0., 0., 10., 89.99, -5.)
+	withinError(t, -sph.EarthRadiusNm*5.*math.Pi/180., sph.AlongTrackDistanceNm(0., 0., 0., 10., 89.99, -5., behind), 1e-3, "Behind")
+
+	// So just behind the start the closest point is the start
+	_, _, _, location := sph.ClosestPointOnSegment(0., 0., 0., 10., 89.99, -5.)
+	if location != sph.SegmentStart {
+		t.Errorf("ClosestPointOnSegment(...) location = %s, want %s", location, sph.SegmentStart)
+	}
+}
+
+func TestClosestPointOnSegmentAntipodalEndpoints(t *testing.T) {
+	_, _, _, _, err := sph.ClosestPointOnSegmentE(0., 0., 0., -180., 1., 5.)
+	isErr(t, sph.ErrDegenerateTrack, err, "Antipodal")
+}
+
+func TestClosestPointOnDegenerateSegment(t *testing.T) {
+
+	_, _, nm, location := sph.ClosestPointOnSegment(1., 1., 1., 1., 2., 2.)
+
+	withinError(t, sph.DistanceInNm(1., 1., 2., 2.), nm, 1e-9, "Distance")
+
+	if location != sph.SegmentStart {
+		t.Errorf("ClosestPointOnSegment(...) location = %s, want %s", location, sph.SegmentStart)
+	}
+}
+
+func TestDistanceToSegmentNm(t *testing.T) {
+	withinError(t, 60.00686673640662, sph.DistanceToSegmentNm(0., 0., 0., 10., 1., .5), .0001, "DistanceToSegmentNm(...)")
+}
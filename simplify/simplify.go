@@ -0,0 +1,110 @@
+/*
+This Simplify package reduces the number of points in long paths (e.g. tracks of thousands of surveillance reports) while keeping
+their shape, using the Douglas-Peucker and Visvalingam-Whyatt algorithms with error metrics measured on the sphere rather than in
+planar degrees.
+
+Both algorithms always keep the first and last points and return the points they keep in their original order. Variants taking a
+Track keep the timestamps (and altitudes) of the points they keep.
+*/
+package simplify
+
+import (
+	"math"
+	dist "stellarsunset/spherical/distance"
+	ll "stellarsunset/spherical/latlong"
+	"stellarsunset/spherical/track"
+)
+
+// Simplifies the path with the Douglas-Peucker algorithm, dropping points until none of those dropped is further than the
+// tolerance from the Great Circle between the kept points either side of it (as measured by CrossTrackDistanceNm).
+func Simplify(points []*ll.LatLong, tolerance *dist.Distance) []*ll.LatLong {
+
+	kept := douglasPeucker(len(points), tolerance.InNauticalMiles(), func(start, end, i int) float64 {
+		return deviation(points[start], points[end], points[i])
+	})
+
+	simplified := make([]*ll.LatLong, len(kept))
+	for i, k := range kept {
+		simplified[i] = points[k]
+	}
+	return simplified
+}
+
+// Simplifies the track with the Douglas-Peucker algorithm measuring the error of each point from where the simplified track would
+// place the object at the time of the point (the synchronized distance), rather than from the nearest point on its path. This
+// preserves changes in speed as well as in direction.
+func SimplifyTrack(t *track.Track, tolerance *dist.Distance) *track.Track {
+
+	points := t.Points()
+	kept := douglasPeucker(len(points), tolerance.InNauticalMiles(), func(start, end, i int) float64 {
+		s, e, p := points[start], points[end], points[i]
+		fraction := float64(p.Time().Sub(s.Time())) / float64(e.Time().Sub(s.Time()))
+		return s.LatLong().InterpolateTo(e.LatLong(), fraction).DistanceInNm(p.LatLong())
+	})
+	return subset(points, kept)
+}
+
+// The distance in NM of the position from the Great Circle through start and end, or from start if they coincide (e.g. when a
+// path returns to where it began)
+func deviation(start, end, position *ll.LatLong) float64 {
+	if *start == *end {
+		return start.DistanceInNm(position)
+	}
+	return math.Abs(position.CrossTrackDistanceNm(start, end))
+}
+
+// Returns the indices of the points kept by the Douglas-Peucker algorithm, given the error of each point from the path between two
+// kept points either side of it
+func douglasPeucker(n int, tolerance float64, deviation func(start, end, i int) float64) []int {
+
+	if n < 3 {
+		return indices(n)
+	}
+
+	keep := make([]bool, n)
+	keep[0], keep[n-1] = true, true
+
+	// Spans of points between kept points still to be checked, processed with a stack rather than recursion as long paths can
+	// otherwise recurse very deeply
+	spans := [][2]int{{0, n - 1}}
+	for len(spans) > 0 {
+		start, end := spans[len(spans)-1][0], spans[len(spans)-1][1]
+		spans = spans[:len(spans)-1]
+
+		furthest, max := -1, tolerance
+		for i := start + 1; i < end; i++ {
+			if d := deviation(start, end, i); d > max {
+				furthest, max = i, d
+			}
+		}
+		if furthest >= 0 {
+			keep[furthest] = true
+			spans = append(spans, [2]int{start, furthest}, [2]int{furthest, end})
+		}
+	}
+
+	var kept []int
+	for i, k := range keep {
+		if k {
+			kept = append(kept, i)
+		}
+	}
+	return kept
+}
+
+func indices(n int) []int {
+	all := make([]int, n)
+	for i := range all {
+		all[i] = i
+	}
+	return all
+}
+
+// A new track made of the points at the provided indices
+func subset(points []*track.Point, kept []int) *track.Track {
+	simplified := make([]*track.Point, len(kept))
+	for i, k := range kept {
+		simplified[i] = points[k]
+	}
+	return track.NewTrack(simplified...)
+}
@@ -0,0 +1,119 @@
+package simplify_test
+
+import (
+	"math"
+	dist "stellarsunset/spherical/distance"
+	ll "stellarsunset/spherical/latlong"
+	"stellarsunset/spherical/simplify"
+	"stellarsunset/spherical/track"
+	"testing"
+	"time"
+)
+
+func isTrue(t *testing.T, condition bool, s string) {
+	if !condition {
+		t.Error(s)
+	}
+}
+
+func isEqual(t *testing.T, expected, actual any) {
+	if expected != actual {
+		t.Errorf("want = %+v, got = %+v", expected, actual)
+	}
+}
+
+// A path heading roughly east from the origin, wandering north and south of the equator by up to the provided amplitude (in NM)
+func wiggly(n int, amplitude float64) []*ll.LatLong {
+	points := make([]*ll.LatLong, n)
+	for i := range points {
+		points[i] = ll.NewLatLong(amplitude/60.*math.Sin(float64(i)*.7)*math.Cos(float64(i)*.13), float64(i)*.05)
+	}
+	return points
+}
+
+func TestSimplifyGreatCircle(t *testing.T) {
+
+	start, end := ll.NewLatLong(40.64, -73.78), ll.NewLatLong(51.47, -.45)
+
+	var points []*ll.LatLong
+	for f := 0.; f <= 1.; f += .01 {
+		points = append(points, start.InterpolateTo(end, f))
+	}
+
+	simplified := simplify.Simplify(points, dist.OfMeters(1.))
+	isEqual(t, 2, len(simplified))
+	isEqual(t, points[0], simplified[0])
+	isEqual(t, points[len(points)-1], simplified[1])
+}
+
+func TestSimplifyKeepsDeviations(t *testing.T) {
+
+	points := []*ll.LatLong{ll.NewLatLong(0., 0.), ll.NewLatLong(5./60., 1.), ll.NewLatLong(0., 2.)}
+
+	isEqual(t, 3, len(simplify.Simplify(points, dist.OfNauticalMiles(4.))))
+	isEqual(t, 2, len(simplify.Simplify(points, dist.OfNauticalMiles(6.))))
+}
+
+func TestSimplifyTolerance(t *testing.T) {
+
+	points := wiggly(500, 3.)
+	tolerance := dist.OfNauticalMiles(.5)
+	simplified := simplify.Simplify(points, tolerance)
+
+	isTrue(t, len(simplified) < len(points), "Simplified")
+
+	// Every point dropped is within the tolerance of the Great Circle between the kept points either side of it
+	k := 0
+	for _, p := range points {
+		if p == simplified[k] {
+			k++
+			continue
+		}
+		isTrue(t, math.Abs(p.CrossTrackDistanceNm(simplified[k-1], simplified[k])) <= tolerance.InNauticalMiles(), "Tolerance")
+	}
+}
+
+func TestSimplifyClosedPath(t *testing.T) {
+
+	points := []*ll.LatLong{
+		ll.NewLatLong(0., 0.),
+		ll.NewLatLong(0., 1.),
+		ll.NewLatLong(1., 1.),
+		ll.NewLatLong(1., 0.),
+		ll.NewLatLong(0., 0.),
+	}
+
+	// The far corner of the loop is kept even though the path returns to its start
+	simplified := simplify.Simplify(points, dist.OfNauticalMiles(1.))
+	isTrue(t, len(simplified) >= 3, "Loop")
+
+	isEqual(t, 0, len(simplify.Simplify(nil, dist.OfNauticalMiles(1.))))
+	isEqual(t, 2, len(simplify.Simplify(points[:2], dist.OfNauticalMiles(1.))))
+}
+
+func TestSimplifyTrack(t *testing.T) {
+
+	start, epoch := ll.NewLatLong(0., 0.), time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	// A straight path east which slows from 6 to 2 NM a minute half way along
+	var points []*track.Point
+	for i := 0; i <= 20; i++ {
+		nm := float64(i) * 6.
+		if i > 10 {
+			nm = 60. + float64(i-10)*2.
+		}
+		points = append(points, track.NewPoint(epoch.Add(time.Duration(i)*time.Minute), start.ProjectOut(90., nm)))
+	}
+	tr := track.NewTrack(points...)
+
+	// The path alone simplifies to a line, but the change in speed is kept when timing is accounted for
+	positions := make([]*ll.LatLong, len(points))
+	for i, p := range points {
+		positions[i] = p.LatLong()
+	}
+	isEqual(t, 2, len(simplify.Simplify(positions, dist.OfNauticalMiles(.1))))
+
+	simplified := simplify.SimplifyTrack(tr, dist.OfNauticalMiles(.1))
+	isEqual(t, 3, simplified.Size())
+	isEqual(t, epoch.Add(10*time.Minute), simplified.Points()[1].Time())
+}
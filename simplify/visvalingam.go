@@ -0,0 +1,129 @@
+package simplify
+
+import (
+	"container/heap"
+	"math"
+	sph "stellarsunset/spherical"
+	"stellarsunset/spherical/area"
+	ll "stellarsunset/spherical/latlong"
+	"stellarsunset/spherical/track"
+	vec "stellarsunset/spherical/vector"
+)
+
+// Simplifies the path with the Visvalingam-Whyatt algorithm, repeatedly dropping the point forming the smallest spherical
+// triangle with its remaining neighbours until every triangle is at least the tolerance. This tends to remove small wiggles
+// more evenly than Douglas-Peucker.
+func SimplifyByArea(points []*ll.LatLong, tolerance *area.Area) []*ll.LatLong {
+
+	kept := visvalingamWhyatt(nVectors(points), tolerance.InSquareNauticalMiles())
+
+	simplified := make([]*ll.LatLong, len(kept))
+	for i, k := range kept {
+		simplified[i] = points[k]
+	}
+	return simplified
+}
+
+// Simplifies the track with the Visvalingam-Whyatt algorithm as SimplifyByArea does, keeping the timestamps of the points kept.
+func SimplifyTrackByArea(t *track.Track, tolerance *area.Area) *track.Track {
+
+	points := t.Points()
+	positions := make([]*ll.LatLong, len(points))
+	for i, p := range points {
+		positions[i] = p.LatLong()
+	}
+	return subset(points, visvalingamWhyatt(nVectors(positions), tolerance.InSquareNauticalMiles()))
+}
+
+func nVectors(points []*ll.LatLong) []*vec.Vec3 {
+	vectors := make([]*vec.Vec3, len(points))
+	for i, p := range points {
+		vectors[i] = p.ToNVector()
+	}
+	return vectors
+}
+
+// The area in square NM of the spherical triangle with the n-vectors as vertices, from its spherical excess
+//
+// See Van Oosterom and Strackee, "The Solid Angle of a Plane Triangle" (1983).
+func triangleArea(a, b, c *vec.Vec3) float64 {
+	excess := 2. * math.Atan2(math.Abs(a.Dot(b.Cross(c))), 1.+a.Dot(b)+b.Dot(c)+c.Dot(a))
+	return excess * sph.EarthRadiusNm * sph.EarthRadiusNm
+}
+
+// A point still in the path along with the area of the triangle it forms with its neighbours
+type vertex struct {
+	index, prev, next int
+	area              float64
+	// Position in the heap, or -1 once removed
+	slot int
+}
+
+type byArea []*vertex
+
+func (this byArea) Len() int {
+	return len(this)
+}
+
+func (this byArea) Less(i, j int) bool {
+	return this[i].area < this[j].area
+}
+
+func (this byArea) Swap(i, j int) {
+	this[i], this[j] = this[j], this[i]
+	this[i].slot, this[j].slot = i, j
+}
+
+func (this *byArea) Push(x any) {
+	v := x.(*vertex)
+	v.slot = len(*this)
+	*this = append(*this, v)
+}
+
+func (this *byArea) Pop() any {
+	old := *this
+	v := old[len(old)-1]
+	v.slot = -1
+	*this = old[:len(old)-1]
+	return v
+}
+
+// Returns the indices of the points kept by the Visvalingam-Whyatt algorithm
+func visvalingamWhyatt(vectors []*vec.Vec3, tolerance float64) []int {
+
+	n := len(vectors)
+	if n < 3 {
+		return indices(n)
+	}
+
+	vertices := make([]*vertex, n)
+	for i := range vertices {
+		vertices[i] = &vertex{index: i, prev: i - 1, next: i + 1, slot: -1}
+	}
+
+	queue := byArea{}
+	for i := 1; i < n-1; i++ {
+		vertices[i].area = triangleArea(vectors[i-1], vectors[i], vectors[i+1])
+		heap.Push(&queue, vertices[i])
+	}
+
+	for queue.Len() > 0 && queue[0].area < tolerance {
+		removed := heap.Pop(&queue).(*vertex)
+		prev, next := vertices[removed.prev], vertices[removed.next]
+		prev.next, next.prev = next.index, prev.index
+
+		// Neighbours' areas never drop below that of the point just removed, so points are removed in order of significance
+		for _, v := range []*vertex{prev, next} {
+			if v.slot >= 0 {
+				v.area = math.Max(removed.area, triangleArea(vectors[v.prev], vectors[v.index], vectors[v.next]))
+				heap.Fix(&queue, v.slot)
+			}
+		}
+	}
+
+	var kept []int
+	for i := 0; i < n; i = vertices[i].next {
+		kept = append(kept, i)
+	}
+	return kept
+}
@@ -0,0 +1,72 @@
+package simplify_test
+
+import (
+	"stellarsunset/spherical/area"
+	ll "stellarsunset/spherical/latlong"
+	"stellarsunset/spherical/polygon"
+	"stellarsunset/spherical/simplify"
+	"stellarsunset/spherical/track"
+	"testing"
+	"time"
+)
+
+func TestSimplifyByArea(t *testing.T) {
+
+	a, b, c := ll.NewLatLong(0., 0.), ll.NewLatLong(1., 1.), ll.NewLatLong(0., 2.)
+	triangle := polygon.NewPolygon(a, b, c).Area()
+
+	// The triangle's area is spherical, so the point is kept below it and dropped above it
+	points := []*ll.LatLong{a, b, c}
+	isEqual(t, 3, len(simplify.SimplifyByArea(points, triangle.Times(.999999))))
+	isEqual(t, 2, len(simplify.SimplifyByArea(points, triangle.Times(1.000001))))
+}
+
+func TestSimplifyByAreaGreatCircle(t *testing.T) {
+
+	start, end := ll.NewLatLong(-33.95, 151.18), ll.NewLatLong(-37.01, 174.79)
+
+	var points []*ll.LatLong
+	for f := 0.; f <= 1.; f += .01 {
+		points = append(points, start.InterpolateTo(end, f))
+	}
+	isEqual(t, 2, len(simplify.SimplifyByArea(points, area.OfSquareMeters(1.))))
+}
+
+func TestSimplifyByAreaOrder(t *testing.T) {
+
+	points := wiggly(500, 3.)
+
+	coarse := simplify.SimplifyByArea(points, area.OfSquareNauticalMiles(1.))
+	fine := simplify.SimplifyByArea(points, area.OfSquareNauticalMiles(.01))
+
+	isTrue(t, len(coarse) < len(fine), "Coarser")
+	isTrue(t, len(fine) < len(points), "Finer")
+
+	// Points are kept in their original order, including the first and last
+	isEqual(t, points[0], coarse[0])
+	isEqual(t, points[len(points)-1], coarse[len(coarse)-1])
+	for i := 1; i < len(coarse); i++ {
+		isTrue(t, coarse[i-1].Longitude() < coarse[i].Longitude(), "Order")
+	}
+}
+
+func TestSimplifyTrackByArea(t *testing.T) {
+
+	epoch := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	var points []*track.Point
+	for i, p := range wiggly(100, 2.) {
+		points = append(points, track.NewPoint(epoch.Add(time.Duration(i)*time.Second), p))
+	}
+	tr := track.NewTrack(points...)
+
+	simplified := simplify.SimplifyTrackByArea(tr, area.OfSquareNauticalMiles(.5))
+	isTrue(t, simplified.Size() < tr.Size(), "Simplified")
+	isEqual(t, tr.Start(), simplified.Start())
+	isEqual(t, tr.End(), simplified.End())
+
+	// Kept points retain their timestamps
+	for _, p := range simplified.Points() {
+		isEqual(t, points[int(p.Time().Sub(epoch)/time.Second)], p)
+	}
+}
@@ -0,0 +1,235 @@
+/*
+This Speed package is intended to make working with Speeds less error prone because (1) all Speed objects are immutable and (2)
+the unit is always required and always accounted for.
+
+Speed is the rate of change of Distance and shares its design, the functions relating the two through a time.Duration are
+provided here.
+*/
+package speed
+
+import (
+	"math"
+	"sort"
+	dist "stellarsunset/spherical/distance"
+	"time"
+)
+
+type Speed struct {
+	amount float64
+	unit   Unit
+}
+
+func Zero() *Speed {
+	return &Speed{0., MetersPerSecond}
+}
+
+func Of(amount float64, unit Unit) *Speed {
+	return &Speed{amount, unit}
+}
+
+func OfKnots(amount float64) *Speed {
+	return &Speed{amount, Knots}
+}
+
+func OfMetersPerSecond(amount float64) *Speed {
+	return &Speed{amount, MetersPerSecond}
+}
+
+func OfKilometersPerHour(amount float64) *Speed {
+	return &Speed{amount, KilometersPerHour}
+}
+
+func OfMilesPerHour(amount float64) *Speed {
+	return &Speed{amount, MilesPerHour}
+}
+
+func OfFeetPerMinute(amount float64) *Speed {
+	return &Speed{amount, FeetPerMinute}
+}
+
+// The average Speed needed to cover the distance in the elapsed time, which is infinite (or NaN for a zero distance) when no
+// time has elapsed.
+func OfDistance(distance *dist.Distance, elapsed time.Duration) *Speed {
+	return OfMetersPerSecond(distance.InMeters() / elapsed.Seconds())
+}
+
+// The Unit this speed was originally defined with
+func (this *Speed) NativeUnit() Unit {
+	return this.unit
+}
+
+func (this *Speed) In(desiredUnit Unit) float64 {
+	if this.unit == desiredUnit {
+		return this.amount
+	} else {
+		return this.amount * (UnitsPerMeterPerSecond(desiredUnit) / UnitsPerMeterPerSecond(this.unit))
+	}
+}
+
+func (this *Speed) InKnots() float64 {
+	return this.In(Knots)
+}
+
+func (this *Speed) InMetersPerSecond() float64 {
+	return this.In(MetersPerSecond)
+}
+
+func (this *Speed) InKilometersPerHour() float64 {
+	return this.In(KilometersPerHour)
+}
+
+func (this *Speed) InMilesPerHour() float64 {
+	return this.In(MilesPerHour)
+}
+
+func (this *Speed) InFeetPerMinute() float64 {
+	return this.In(FeetPerMinute)
+}
+
+// The Distance covered travelling at this speed for the elapsed time
+func (this *Speed) DistanceIn(elapsed time.Duration) *dist.Distance {
+	return dist.OfMeters(this.InMetersPerSecond() * elapsed.Seconds())
+}
+
+// The time taken to cover the distance at this speed, saturating at the longest representable time.Duration (of either sign) if
+// it would overflow, e.g. when this speed is zero.
+func (this *Speed) TimeToCover(distance *dist.Distance) time.Duration {
+	seconds := distance.InMeters() / this.InMetersPerSecond()
+	switch {
+	case math.IsNaN(seconds):
+		return 0
+	case seconds >= math.MaxInt64/float64(time.Second):
+		return time.Duration(math.MaxInt64)
+	case seconds <= math.MinInt64/float64(time.Second):
+		return time.Duration(math.MinInt64)
+	}
+	return time.Duration(math.Round(seconds * float64(time.Second)))
+}
+
+func (this *Speed) Negate() *Speed {
+	return Of(-this.amount, this.unit)
+}
+
+func (this *Speed) Abs() *Speed {
+	return Of(math.Abs(this.amount), this.unit)
+}
+
+func (this *Speed) IsPositive() bool {
+	return this.amount > 0.
+}
+
+func (this *Speed) IsNegative() bool {
+	return this.amount < 0.
+}
+
+func (this *Speed) IsZero() bool {
+	return this.amount == 0.
+}
+
+func (this *Speed) Times(scalar float64) *Speed {
+	return Of(this.amount*scalar, this.unit)
+}
+
+func (this *Speed) Plus(that *Speed) *Speed {
+	return Of(this.amount+that.In(this.unit), this.unit)
+}
+
+func (this *Speed) Minus(that *Speed) *Speed {
+	return Of(this.amount-that.In(this.unit), this.unit)
+}
+
+func (this *Speed) IsLessThan(that *Speed) bool {
+	return this.amount < that.In(this.unit)
+}
+
+func (this *Speed) IsLessThanOrEqualTo(that *Speed) bool {
+	return this.amount <= that.In(this.unit)
+}
+
+func (this *Speed) IsGreaterThan(that *Speed) bool {
+	return this.amount > that.In(this.unit)
+}
+
+func (this *Speed) IsGreaterThanOrEqualTo(that *Speed) bool {
+	return this.amount >= that.In(this.unit)
+}
+
+type byAmount []Speed
+
+func (a byAmount) Len() int {
+	return len(a)
+}
+
+func (a byAmount) Swap(i, j int) {
+	a[i], a[j] = a[j], a[i]
+}
+
+func (a byAmount) Less(i, j int) bool {
+	return a[i].IsLessThan(&a[j])
+}
+
+// Sort the provided slice of speeds based on their unit-aligned amounts
+func Sort(speeds []Speed) {
+	sort.Sort(byAmount(speeds))
+}
+
+func Sum(speeds []Speed) *Speed {
+	switch l := len(speeds); l {
+	case 0:
+		return Zero()
+	case 1:
+		return &speeds[0]
+	default:
+		amount, unit := speeds[0].amount, speeds[0].unit
+		for i := 1; i < l; i++ {
+			amount += speeds[i].In(unit)
+		}
+		return Of(amount, unit)
+	}
+}
+
+func Min(one, two *Speed) *Speed {
+	if one.IsLessThanOrEqualTo(two) {
+		return one
+	} else {
+		return two
+	}
+}
+
+func MinOf(speeds []Speed) *Speed {
+	switch l := len(speeds); l {
+	case 0:
+		return nil
+	case 1:
+		return &speeds[0]
+	default:
+		min := &speeds[0]
+		for i := 1; i < l; i++ {
+			min = Min(min, &speeds[i])
+		}
+		return min
+	}
+}
+
+func Max(one, two *Speed) *Speed {
+	if one.IsGreaterThanOrEqualTo(two) {
+		return one
+	} else {
+		return two
+	}
+}
+
+func MaxOf(speeds []Speed) *Speed {
+	switch l := len(speeds); l {
+	case 0:
+		return nil
+	case 1:
+		return &speeds[0]
+	default:
+		max := &speeds[0]
+		for i := 1; i < l; i++ {
+			max = Max(max, &speeds[i])
+		}
+		return max
+	}
+}
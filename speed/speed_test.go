@@ -0,0 +1,119 @@
+package speed_test
+
+import (
+	"math"
+	dist "stellarsunset/spherical/distance"
+	"stellarsunset/spherical/speed"
+	"testing"
+	"time"
+)
+
+func isTrue(t *testing.T, condition bool, s string) {
+	if !condition {
+		t.Error(s)
+	}
+}
+
+func isEqual(t *testing.T, expected, actual any) {
+	if expected != actual {
+		t.Errorf("want = %+v, got = %+v", expected, actual)
+	}
+}
+
+func withinFractionOfExpected(t *testing.T, expected, actual, percentError float64, s string) {
+	if math.Abs(expected-actual) > (percentError * math.Abs(expected)) {
+		t.Errorf("%s: want = %f, got = %f, tol = %f", s, expected, actual, percentError)
+	}
+}
+
+func TestIn(t *testing.T) {
+
+	tol, oneKnot := .00001, speed.OfKnots(1.)
+
+	withinFractionOfExpected(t, 1., oneKnot.InKnots(), tol, "InKnots()")
+	withinFractionOfExpected(t, .514444, oneKnot.InMetersPerSecond(), tol, "InMetersPerSecond()")
+	withinFractionOfExpected(t, 1.852, oneKnot.InKilometersPerHour(), tol, "InKilometersPerHour()")
+	withinFractionOfExpected(t, 1.150779, oneKnot.InMilesPerHour(), tol, "InMilesPerHour()")
+	withinFractionOfExpected(t, 101.268591, oneKnot.InFeetPerMinute(), tol, "InFeetPerMinute()")
+
+	withinFractionOfExpected(t, 60., speed.OfMilesPerHour(60.).In(speed.MilesPerHour), tol, "In(MilesPerHour)")
+	withinFractionOfExpected(t, 1000., speed.OfFeetPerMinute(1000.).InFeetPerMinute(), tol, "InFeetPerMinute()")
+	withinFractionOfExpected(t, 100., speed.OfKilometersPerHour(360.).InMetersPerSecond(), tol, "InMetersPerSecond()")
+}
+
+func TestArithmetic(t *testing.T) {
+
+	tenKnots, fiveMps := speed.OfKnots(10.), speed.OfMetersPerSecond(5.)
+
+	withinFractionOfExpected(t, 10.+5.*3600./1852., tenKnots.Plus(fiveMps).InKnots(), 1e-12, "Plus()")
+	withinFractionOfExpected(t, 10.-5.*3600./1852., tenKnots.Minus(fiveMps).InKnots(), 1e-12, "Minus()")
+	withinFractionOfExpected(t, 20., tenKnots.Times(2.).InKnots(), 1e-12, "Times()")
+
+	isEqual(t, *speed.OfKnots(-10.), *tenKnots.Negate())
+	isEqual(t, *tenKnots, *tenKnots.Negate().Abs())
+	isTrue(t, tenKnots.Negate().IsNegative(), "IsNegative()")
+	isTrue(t, tenKnots.IsPositive(), "IsPositive()")
+	isTrue(t, speed.Zero().IsZero(), "IsZero()")
+}
+
+func TestComparisonMethods(t *testing.T) {
+
+	oneKnot, oneMps := speed.OfKnots(1.), speed.OfMetersPerSecond(1.)
+	oneKmh, knotInMps := speed.OfKilometersPerHour(1.852), speed.OfMetersPerSecond(1852./3600.)
+
+	isTrue(t, oneKnot.IsLessThan(oneMps), "1kt < 1m/s")
+	isTrue(t, oneKnot.IsLessThanOrEqualTo(oneMps), "1kt <= 1m/s")
+	isTrue(t, oneMps.IsGreaterThan(oneKnot), "1m/s > 1kt")
+	isTrue(t, oneMps.IsGreaterThanOrEqualTo(oneKnot), "1m/s >= 1kt")
+
+	isTrue(t, oneKmh.IsLessThanOrEqualTo(knotInMps.Times(1.000001)), "1.852km/h <= 1kt")
+	isTrue(t, oneKmh.IsGreaterThanOrEqualTo(knotInMps.Times(.999999)), "1.852km/h >= 1kt")
+}
+
+func TestSortSumMinMax(t *testing.T) {
+
+	oneKnot, zero, oneMps, negative := speed.OfKnots(1.), speed.Zero(), speed.OfMetersPerSecond(1.), speed.OfFeetPerMinute(-100.)
+	speeds := []speed.Speed{*oneMps, *zero, *oneKnot, *negative}
+
+	speed.Sort(speeds)
+
+	isEqual(t, *negative, speeds[0])
+	isEqual(t, *zero, speeds[1])
+	isEqual(t, *oneKnot, speeds[2])
+	isEqual(t, *oneMps, speeds[3])
+
+	isEqual(t, *negative, *speed.MinOf(speeds))
+	isEqual(t, *oneMps, *speed.MaxOf(speeds))
+	isTrue(t, speed.MinOf(nil) == nil, "MinOf(nil)")
+
+	isEqual(t, *speed.Zero(), *speed.Sum(nil))
+	withinFractionOfExpected(t, 1.+(1.-100.*.3048/60.)*3600./1852., speed.Sum(speeds).InKnots(), 1e-12, "Sum()")
+}
+
+func TestOfDistance(t *testing.T) {
+
+	s := speed.OfDistance(dist.OfNauticalMiles(240.), 90*time.Minute)
+	withinFractionOfExpected(t, 160., s.InKnots(), 1e-12, "OfDistance()")
+
+	isTrue(t, math.IsInf(speed.OfDistance(dist.OfMeters(1.), 0).InKnots(), 1), "Infinite")
+}
+
+func TestDistanceIn(t *testing.T) {
+
+	d := speed.OfKnots(450.).DistanceIn(20 * time.Minute)
+	withinFractionOfExpected(t, 150., d.InNauticalMiles(), 1e-12, "DistanceIn()")
+
+	d = speed.OfFeetPerMinute(-1500.).DistanceIn(2 * time.Minute)
+	withinFractionOfExpected(t, -3000., d.InFeet(), 1e-12, "Descending")
+}
+
+func TestTimeToCover(t *testing.T) {
+
+	isEqual(t, 40*time.Minute, speed.OfKnots(180.).TimeToCover(dist.OfNauticalMiles(120.)))
+	isEqual(t, 90*time.Second, speed.OfMetersPerSecond(10.).TimeToCover(dist.OfKilometers(.9)))
+
+	// Durations saturate rather than overflowing
+	isEqual(t, time.Duration(math.MaxInt64), speed.Zero().TimeToCover(dist.OfNauticalMiles(1.)))
+	isEqual(t, time.Duration(math.MinInt64), speed.OfKnots(-1e-300).TimeToCover(dist.OfNauticalMiles(1.)))
+	isEqual(t, time.Duration(0), speed.Zero().TimeToCover(dist.Zero()))
+}
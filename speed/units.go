@@ -0,0 +1,32 @@
+package speed
+
+type Unit int
+
+const (
+	Knots Unit = iota
+	MetersPerSecond
+	KilometersPerHour
+	MilesPerHour
+	FeetPerMinute
+)
+
+type info struct {
+	perMeterPerSecond float64
+	abbr              string
+}
+
+var units = [...]info{
+	Knots:             {perMeterPerSecond: 3600. / 1852., abbr: "kt"},
+	MetersPerSecond:   {perMeterPerSecond: 1., abbr: "m/s"},
+	KilometersPerHour: {perMeterPerSecond: 3.6, abbr: "km/h"},
+	MilesPerHour:      {perMeterPerSecond: 3600. / (.3048 * 5280.), abbr: "mph"},
+	FeetPerMinute:     {perMeterPerSecond: 60. / .3048, abbr: "ft/min"},
+}
+
+func UnitsPerMeterPerSecond(unit Unit) float64 {
+	return units[unit].perMeterPerSecond
+}
+
+func Abbr(unit Unit) string {
+	return units[unit].abbr
+}
@@ -0,0 +1,22 @@
+package speed_test
+
+import (
+	"stellarsunset/spherical/speed"
+	"testing"
+)
+
+func TestMetersPerSecondPerUnit(t *testing.T) {
+
+	want := 1.
+	if got := speed.UnitsPerMeterPerSecond(speed.MetersPerSecond); got != want {
+		t.Errorf("UnitsPerMeterPerSecond(MetersPerSecond) = %f, want %f", got, want)
+	}
+}
+
+func TestAbbreviation(t *testing.T) {
+
+	want := speed.Knots
+	if got := speed.OfKnots(1.).NativeUnit(); got != want {
+		t.Errorf("OfKnots(1.) = %q, want %q", speed.Abbr(got), speed.Abbr(want))
+	}
+}
@@ -6,13 +6,14 @@
 //
 // Note: all methods in this class referenceing latitude/longitude are implicitly expecting them in degrees
 //
-// This package is left independent of the other utility packages provided in this repo to allow it to be copied out
-// and or referenced independently if desired.
+// This package is left independent of the other utility packages provided in this repo (other than the dependency free
+// vector package) to allow it to be copied out and or referenced independently if desired.
 package spherical
 
 import (
 	"fmt"
 	"math"
+	vec "stellarsunset/spherical/vector"
 )
 
 const (
@@ -123,6 +124,14 @@ func CrossTrackDistanceNm(startLat, startLon, endLat, endLon, posLat, posLon flo
 // Note: If CTD is invalid (i.e. it is not the correct cross track distance for the {startPoint, endPoint, and p} then it may
 // return NaN.
 func AlongTrackDistanceNm(startLat, startLon, endLat, endLon, posLat, posLon, crossTrackDistanceNm float64) float64 {
+	atd, err := alongTrackDistanceNmE(startLat, startLon, endLat, endLon, posLat, posLon, crossTrackDistanceNm)
+	if err != nil {
+		panic(err)
+	}
+	return atd
+}
+
+func alongTrackDistanceNmE(startLat, startLon, endLat, endLon, posLat, posLon, crossTrackDistanceNm float64) (float64, error) {
 
 	relativeAngle := angleDifference(CourseInDegrees(startLat, startLon, endLat, endLon), CourseInDegrees(startLat, startLon, posLat, posLon))
 
@@ -136,17 +145,18 @@ func AlongTrackDistanceNm(startLat, startLon, endLat, endLon, posLat, posLon, cr
 	posDistance := DistanceInNm(startLat, startLon, posLat, posLon)
 	cosCtd, cosPtd := math.Cos(distanceInRadians(crossTrackDistanceNm)), math.Cos(distanceInRadians(posDistance))
 
-	// In rare cases this ratio can exceed 1 due to numeric error. We've seen cases (refer to the unit tests)
-	// where ratio = 1.0000000000000002
-	ratio := cosPtd / cosCtd
-	if (-1.-tolerance) > ratio || (1.+tolerance) < ratio {
-		panic(fmt.Sprintf("Cannot compute acos(%f). Inputs were: Start(%f, %f), End(%f, %f), Position(%f, %f), CTD(%f)",
-			ratio, startLat, startLon, endLat, endLon, posLat, posLon, crossTrackDistanceNm))
+	// cos(ptd) = cos(atd) * cos(ctd), so the squared sine of the along track distance scaled by cos(ctd) is the difference below.
+	// In rare cases this can fall below 0 due to numeric error, we've seen cases (refer to the unit tests) where the ratio of
+	// the cosines was 1.0000000000000002
+	scaledSin := cosCtd*cosCtd - cosPtd*cosPtd
+	if scaledSin < -tolerance {
+		return math.NaN(), fmt.Errorf("%w: Cannot compute acos(%f). Inputs were: Start(%f, %f), End(%f, %f), Position(%f, %f), CTD(%f)",
+			ErrDegenerateTrack, cosPtd/cosCtd, startLat, startLon, endLat, endLon, posLat, posLon, crossTrackDistanceNm)
 	}
 
-	// Clamp down the ratio from the tolerant range to the "valid acos range"
-	ratio = math.Min(math.Max(ratio, -1.), 1.)
-	return sign * distanceInNm(math.Acos(ratio))
+	// Near the poles of the great circle both cosines vanish, so rather than taking acos of their (poorly conditioned) ratio take
+	// the angle from its scaled sine and cosine, with the sign of the sine given by the side of the start the position is on
+	return distanceInNm(math.Atan2(sign*math.Sqrt(math.Max(scaledSin, 0.)), cosPtd)), nil
 }
 
 func asinReal(x float64) float64 {
@@ -181,14 +191,45 @@ func mod(x, y float64) float64 {
 	return z
 }
 
+// Convert a (latitude, longitude) in degrees to a unit vector from the center of the Earth (an n-vector)
+func toNVector(lat, lon float64) *vec.Vec3 {
+	sinLat, cosLat := math.Sincos(toRadians(lat))
+	sinLon, cosLon := math.Sincos(toRadians(lon))
+	return vec.Of(cosLat*cosLon, cosLat*sinLon, sinLat)
+}
+
+// Convert a (not necessarily unit) vector from the center of the Earth to a (latitude, longitude) in degrees
+func fromNVector(v *vec.Vec3) (latitude, longitude float64) {
+	return toDegrees(math.Atan2(v.Z(), math.Hypot(v.X(), v.Y()))), mod(toDegrees(math.Atan2(v.Y(), v.X()))+180., 360.) - 180.
+}
+
+// Unit vector tangent to the surface at the provided location pointing along the provided course
+func direction(lat, lon, courseDegrees float64) *vec.Vec3 {
+
+	sinLat, cosLat := math.Sincos(toRadians(lat))
+	sinLon, cosLon := math.Sincos(toRadians(lon))
+	sinCrs, cosCrs := math.Sincos(toRadians(courseDegrees))
+
+	// Local north and east unit vectors
+	north := vec.Of(-sinLat*cosLon, -sinLat*sinLon, cosLat)
+	east := vec.Of(-sinLon, cosLon, 0.)
+
+	return north.Times(cosCrs).Plus(east.Times(sinCrs))
+}
+
+// Unit normal to the Great Circle passing through the provided location on the provided course
+func greatCircleNormal(lat, lon, courseDegrees float64) *vec.Vec3 {
+	return toNVector(lat, lon).Cross(direction(lat, lon, courseDegrees))
+}
+
 // Convert a distance (in nautical miles) to the corresponding amount of "great circle radians"
 func distanceInRadians(nauticalMiles float64) float64 {
-	return (math.Pi / (180. * 60.)) * nauticalMiles
+	return nauticalMiles / EarthRadiusNm
 }
 
 // Convert an amount of "great circle radians" to the corresponding number of nautical miles
 func distanceInNm(radians float64) float64 {
-	return ((180. * 60.) / math.Pi) * radians
+	return EarthRadiusNm * radians
 }
 
 // Replicated from course.go so the import isn't required for this class
@@ -0,0 +1,52 @@
+package track
+
+import (
+	crs "stellarsunset/spherical/course"
+	dist "stellarsunset/spherical/distance"
+	spd "stellarsunset/spherical/speed"
+	"time"
+)
+
+// A Leg is the Great Circle between two consecutive points of a track
+type Leg struct {
+	from, to *Point
+}
+
+func (this *Leg) From() *Point {
+	return this.from
+}
+
+func (this *Leg) To() *Point {
+	return this.to
+}
+
+func (this *Leg) Duration() time.Duration {
+	return this.to.Time().Sub(this.from.Time())
+}
+
+func (this *Leg) Distance() *dist.Distance {
+	return this.from.LatLong().DistanceTo(this.to.LatLong())
+}
+
+// The initial course of the leg, which is due north if both points are at the same position
+func (this *Leg) Course() *crs.Course {
+	return this.from.LatLong().CourseTo(this.to.LatLong())
+}
+
+// The average speed implied by the distance covered over the duration of the leg
+func (this *Leg) Speed() *spd.Speed {
+	return spd.OfDistance(this.Distance(), this.Duration())
+}
+
+// The point on the leg at the time, which is assumed to be within it
+func (this *Leg) pointAt(at time.Time) *Point {
+
+	fraction := float64(at.Sub(this.from.Time())) / float64(this.Duration())
+	position := this.from.LatLong().InterpolateTo(this.to.LatLong(), fraction)
+
+	if !this.from.HasAltitude() || !this.to.HasAltitude() {
+		return NewPoint(at, position)
+	}
+	from, to := this.from.altitude, this.to.altitude
+	return NewPointWithAltitude(at, position, from.Plus(to.Minus(from).Times(fraction)))
+}
@@ -0,0 +1,31 @@
+package track_test
+
+import (
+	"math"
+	ll "stellarsunset/spherical/latlong"
+	"stellarsunset/spherical/track"
+	"testing"
+	"time"
+)
+
+func TestLegs(t *testing.T) {
+
+	a, b, c := ll.NewLatLong(0., 0.), ll.NewLatLong(0., 1.), ll.NewLatLong(1., 1.)
+	tr := track.NewTrack(track.NewPoint(at(0), a), track.NewPoint(at(1800), b), track.NewPoint(at(2400), c))
+
+	legs := tr.Legs()
+	isEqual(t, 2, len(legs))
+
+	isEqual(t, a, legs[0].From().LatLong())
+	isEqual(t, b, legs[0].To().LatLong())
+	isEqual(t, 30*time.Minute, legs[0].Duration())
+	withinError(t, a.DistanceInNm(b), legs[0].Distance().InNauticalMiles(), 1e-12)
+
+	courses, speeds := tr.Courses(), tr.Speeds()
+	withinError(t, 90., courses[0].InDegrees(), 1e-9)
+	withinError(t, 0., math.Mod(courses[1].InDegrees(), 360.), 1e-9)
+	withinError(t, 2.*a.DistanceInNm(b), speeds[0].InKnots(), 1e-9)
+	withinError(t, 6.*b.DistanceInNm(c), speeds[1].InKnots(), 1e-9)
+
+	isEqual(t, 0, len(track.NewTrack(track.NewPoint(at(0), a)).Legs()))
+}
@@ -0,0 +1,218 @@
+/*
+This Track package models the path of a moving object (e.g. an aircraft or vessel) as a sequence of timestamped positions such as
+surveillance reports, with optional altitudes.
+
+Between points objects are assumed to follow the Great Circle at a constant speed, and altitude to change linearly.
+*/
+package track
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	crs "stellarsunset/spherical/course"
+	dist "stellarsunset/spherical/distance"
+	ll "stellarsunset/spherical/latlong"
+	spd "stellarsunset/spherical/speed"
+	"time"
+)
+
+var (
+	// Returned when attempting to create a track without any points
+	ErrEmptyTrack = errors.New("Track requires at least 1 point")
+	// Returned when two points of a track share a timestamp but not a position and altitude
+	ErrConflictingPoints = errors.New("Track points share a timestamp but differ")
+	ErrTimeOutOfRange    = errors.New("Time is outside the track")
+	// Returned when resampling a track with a step that isn't positive
+	ErrInvalidStep = errors.New("Resampling step must be positive")
+)
+
+// A LatLong at a point in time, with an altitude if known
+type Point struct {
+	ll.TimedLatLong
+	altitude *dist.Distance
+}
+
+func NewPoint(at time.Time, position *ll.LatLong) *Point {
+	return &Point{*ll.NewTimedLatLong(at, position), nil}
+}
+
+func NewPointWithAltitude(at time.Time, position *ll.LatLong, altitude *dist.Distance) *Point {
+	return &Point{*ll.NewTimedLatLong(at, position), altitude}
+}
+
+// Creates a point without an altitude from the TimedLatLong, e.g. one extrapolated by LatLong.DeadReckonEvery
+func NewPointFromTimedLatLong(timed *ll.TimedLatLong) *Point {
+	return &Point{*timed, nil}
+}
+
+// The altitude of the point, or nil if it isn't known
+func (this *Point) Altitude() *dist.Distance {
+	return this.altitude
+}
+
+func (this *Point) HasAltitude() bool {
+	return this.altitude != nil
+}
+
+// Whether the two points are at the same time, position and altitude
+func (this *Point) sameAs(that *Point) bool {
+	if !this.Time().Equal(that.Time()) || *this.LatLong() != *that.LatLong() || this.HasAltitude() != that.HasAltitude() {
+		return false
+	}
+	return !this.HasAltitude() || this.altitude.InMeters() == that.altitude.InMeters()
+}
+
+// A Track is an immutable sequence of points ordered by time, with no two at the same time
+type Track struct {
+	points []*Point
+}
+
+// Creates a new Track from the provided points, panicking if TryNewTrack would return an error.
+func NewTrack(points ...*Point) *Track {
+	track, err := TryNewTrack(points...)
+	if err != nil {
+		panic(err)
+	}
+	return track
+}
+
+// Creates a new Track from the provided points, which may be provided in any order as they are sorted by time (as reports often
+// arrive out of order). Repeated points (e.g. the same report received twice) are dropped, but points sharing a timestamp that
+// differ in position or altitude return an error matching ErrConflictingPoints as it's ambiguous which is correct. Returns
+// ErrEmptyTrack if no points are provided.
+func TryNewTrack(points ...*Point) (*Track, error) {
+
+	if len(points) == 0 {
+		return nil, ErrEmptyTrack
+	}
+
+	sorted := make([]*Point, len(points))
+	copy(sorted, points)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time().Before(sorted[j].Time())
+	})
+
+	unique := sorted[:1]
+	for _, p := range sorted[1:] {
+		last := unique[len(unique)-1]
+		if !p.Time().Equal(last.Time()) {
+			unique = append(unique, p)
+		} else if !p.sameAs(last) {
+			return nil, fmt.Errorf("%w: at %s", ErrConflictingPoints, p.Time().Format(time.RFC3339Nano))
+		}
+	}
+	return &Track{unique}, nil
+}
+
+// The points of the track in time order
+func (this *Track) Points() []*Point {
+	points := make([]*Point, len(this.points))
+	copy(points, this.points)
+	return points
+}
+
+func (this *Track) Size() int {
+	return len(this.points)
+}
+
+func (this *Track) Start() time.Time {
+	return this.points[0].Time()
+}
+
+func (this *Track) End() time.Time {
+	return this.points[len(this.points)-1].Time()
+}
+
+func (this *Track) Duration() time.Duration {
+	return this.End().Sub(this.Start())
+}
+
+// The total length of the Great Circle legs between the points of the track
+func (this *Track) Length() *dist.Distance {
+	nm := 0.
+	for i := 1; i < len(this.points); i++ {
+		nm += this.points[i-1].LatLong().DistanceInNm(this.points[i].LatLong())
+	}
+	return dist.OfNauticalMiles(nm)
+}
+
+// Returns the interpolated point at the provided time, or an error matching ErrTimeOutOfRange if it is before the start or after
+// the end of the track. The altitude is interpolated when both neighbouring points have one, and is otherwise unknown.
+func (this *Track) PositionAt(at time.Time) (*Point, error) {
+
+	if at.Before(this.Start()) || at.After(this.End()) {
+		return nil, fmt.Errorf("%w: %s not within [%s, %s]", ErrTimeOutOfRange, at.Format(time.RFC3339Nano),
+			this.Start().Format(time.RFC3339Nano), this.End().Format(time.RFC3339Nano))
+	}
+
+	// The first point after the time, so the time falls on the leg ending there
+	i := sort.Search(len(this.points), func(i int) bool {
+		return this.points[i].Time().After(at)
+	})
+	if i == len(this.points) {
+		return this.points[i-1], nil
+	}
+	return this.leg(i).pointAt(at), nil
+}
+
+// Returns a new track with points every step from the start of this one up to and including its end, panicking if ResampleE
+// would return an error. The end is included even if it isn't a whole number of steps from the start.
+func (this *Track) Resample(step time.Duration) *Track {
+	resampled, err := this.ResampleE(step)
+	if err != nil {
+		panic(err)
+	}
+	return resampled
+}
+
+// Resamples the track as Resample does, or returns an error matching ErrInvalidStep if the step isn't positive.
+func (this *Track) ResampleE(step time.Duration) (*Track, error) {
+
+	if step <= 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidStep, step)
+	}
+
+	var points []*Point
+	leg := 1
+	for offset := time.Duration(0); offset < this.Duration(); offset += step {
+		at := this.Start().Add(offset)
+		for this.points[leg].Time().Before(at) || this.points[leg].Time().Equal(at) {
+			leg++
+		}
+		points = append(points, this.leg(leg).pointAt(at))
+	}
+	return &Track{append(points, this.points[len(this.points)-1])}, nil
+}
+
+// The legs between consecutive points of the track, one fewer than the number of points
+func (this *Track) Legs() []*Leg {
+	legs := make([]*Leg, len(this.points)-1)
+	for i := range legs {
+		legs[i] = this.leg(i + 1)
+	}
+	return legs
+}
+
+// The courses of each of the legs of the track
+func (this *Track) Courses() []*crs.Course {
+	courses := make([]*crs.Course, len(this.points)-1)
+	for i := range courses {
+		courses[i] = this.leg(i + 1).Course()
+	}
+	return courses
+}
+
+// The implied speeds of each of the legs of the track
+func (this *Track) Speeds() []*spd.Speed {
+	speeds := make([]*spd.Speed, len(this.points)-1)
+	for i := range speeds {
+		speeds[i] = this.leg(i + 1).Speed()
+	}
+	return speeds
+}
+
+// The leg ending at the point with the given index
+func (this *Track) leg(end int) *Leg {
+	return &Leg{this.points[end-1], this.points[end]}
+}
@@ -0,0 +1,175 @@
+package track_test
+
+import (
+	"errors"
+	"math"
+	crs "stellarsunset/spherical/course"
+	dist "stellarsunset/spherical/distance"
+	ll "stellarsunset/spherical/latlong"
+	spd "stellarsunset/spherical/speed"
+	"stellarsunset/spherical/track"
+	"testing"
+	"time"
+)
+
+func isTrue(t *testing.T, condition bool, s string) {
+	if !condition {
+		t.Error(s)
+	}
+}
+
+func isEqual(t *testing.T, expected, actual any) {
+	if expected != actual {
+		t.Errorf("want = %+v, got = %+v", expected, actual)
+	}
+}
+
+func withinError(t *testing.T, expected, actual, tolerance float64) {
+	if math.Abs(expected-actual) > tolerance {
+		t.Errorf("want = %f, got = %f, tol = %f", expected, actual, tolerance)
+	}
+}
+
+var epoch = time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+func at(seconds int) time.Time {
+	return epoch.Add(time.Duration(seconds) * time.Second)
+}
+
+func TestPointFromTimedLatLong(t *testing.T) {
+
+	// Dead reckoned positions become the points of a track directly
+	positions := ll.NewLatLong(0., 0.).DeadReckonEvery(epoch, crs.OfDegrees(90.), spd.OfKnots(360.), 0., 10*time.Second, time.Minute)
+
+	var points []*track.Point
+	for _, p := range positions {
+		points = append(points, track.NewPointFromTimedLatLong(p))
+	}
+
+	tr := track.NewTrack(points...)
+	isEqual(t, 7, tr.Size())
+	isEqual(t, time.Minute, tr.Duration())
+	isTrue(t, !tr.Points()[0].HasAltitude(), "Altitude")
+	withinError(t, 6., tr.Length().InNauticalMiles(), 1e-6)
+
+	// And points are TimedLatLongs too
+	p := track.NewPointWithAltitude(at(5), ll.NewLatLong(1., 2.), dist.OfFeet(1000.))
+	isEqual(t, at(5), p.TimedLatLong.Time())
+	isEqual(t, 2., p.TimedLatLong.LatLong().Longitude())
+}
+
+func TestNewTrackSortsPoints(t *testing.T) {
+
+	a, b, c := ll.NewLatLong(0., 0.), ll.NewLatLong(0., 1.), ll.NewLatLong(1., 1.)
+	tr := track.NewTrack(track.NewPoint(at(60), b), track.NewPoint(at(120), c), track.NewPoint(at(0), a))
+
+	points := tr.Points()
+	isEqual(t, 3, tr.Size())
+	isEqual(t, a, points[0].LatLong())
+	isEqual(t, b, points[1].LatLong())
+	isEqual(t, c, points[2].LatLong())
+
+	isEqual(t, at(0), tr.Start())
+	isEqual(t, at(120), tr.End())
+	isEqual(t, 2*time.Minute, tr.Duration())
+}
+
+func TestNewTrackDuplicates(t *testing.T) {
+
+	a, b := ll.NewLatLong(0., 0.), ll.NewLatLong(0., 1.)
+
+	// Repeats of the same point are dropped
+	tr := track.NewTrack(track.NewPoint(at(0), a), track.NewPoint(at(60), b), track.NewPoint(at(0), ll.NewLatLong(0., 0.)))
+	isEqual(t, 2, tr.Size())
+
+	// But conflicting ones are rejected
+	_, err := track.TryNewTrack(track.NewPoint(at(0), a), track.NewPoint(at(0), b))
+	isTrue(t, errors.Is(err, track.ErrConflictingPoints), "Position")
+
+	_, err = track.TryNewTrack(track.NewPoint(at(0), a), track.NewPointWithAltitude(at(0), a, dist.OfFeet(1000.)))
+	isTrue(t, errors.Is(err, track.ErrConflictingPoints), "Altitude")
+
+	_, err = track.TryNewTrack()
+	isTrue(t, errors.Is(err, track.ErrEmptyTrack), "Empty")
+}
+
+func TestPositionAt(t *testing.T) {
+
+	a, b := ll.NewLatLong(40., -74.), ll.NewLatLong(51., 0.)
+	tr := track.NewTrack(
+		track.NewPointWithAltitude(at(0), a, dist.OfFeet(35000.)),
+		track.NewPointWithAltitude(at(3600), b, dist.OfFeet(37000.)),
+		track.NewPoint(at(7200), ll.NewLatLong(52., 5.)),
+	)
+
+	quarter, err := tr.PositionAt(at(900))
+	isTrue(t, err == nil, "Error")
+	withinError(t, 0., quarter.LatLong().DistanceInNm(a.InterpolateTo(b, .25)), 1e-9)
+	withinError(t, 35500., quarter.Altitude().InFeet(), 1e-6)
+	isEqual(t, at(900), quarter.Time())
+
+	// Altitudes are unknown on legs where either end lacks one
+	later, _ := tr.PositionAt(at(5400))
+	isTrue(t, !later.HasAltitude(), "Altitude")
+
+	end, _ := tr.PositionAt(at(7200))
+	withinError(t, 0., end.LatLong().DistanceInNm(ll.NewLatLong(52., 5.)), 0.)
+
+	start, _ := tr.PositionAt(at(0))
+	withinError(t, 0., start.LatLong().DistanceInNm(a), 1e-12)
+
+	_, err = tr.PositionAt(at(-1))
+	isTrue(t, errors.Is(err, track.ErrTimeOutOfRange), "Before")
+
+	_, err = tr.PositionAt(at(7201))
+	isTrue(t, errors.Is(err, track.ErrTimeOutOfRange), "After")
+}
+
+func TestResample(t *testing.T) {
+
+	tr := track.NewTrack(
+		track.NewPoint(at(0), ll.NewLatLong(0., 0.)),
+		track.NewPoint(at(25), ll.NewLatLong(0., .25)),
+		track.NewPoint(at(95), ll.NewLatLong(.5, .5)),
+	)
+
+	resampled := tr.Resample(10 * time.Second)
+	points := resampled.Points()
+	isEqual(t, 11, len(points))
+
+	for i, p := range points[:10] {
+		isEqual(t, at(10*i), p.Time())
+		expected, _ := tr.PositionAt(at(10 * i))
+		withinError(t, 0., expected.LatLong().DistanceInNm(p.LatLong()), 1e-12)
+	}
+	isEqual(t, at(95), points[10].Time())
+
+	// A single point track resamples to itself
+	isEqual(t, 1, track.NewTrack(track.NewPoint(at(0), ll.NewLatLong(0., 0.))).Resample(time.Second).Size())
+}
+
+func TestResampleE(t *testing.T) {
+	tr := track.NewTrack(track.NewPoint(at(0), ll.NewLatLong(0., 0.)), track.NewPoint(at(30), ll.NewLatLong(0., .25)))
+
+	resampled, err := tr.ResampleE(10 * time.Second)
+	isTrue(t, err == nil, "Error")
+	isEqual(t, 4, resampled.Size())
+
+	_, err = tr.ResampleE(0)
+	isTrue(t, errors.Is(err, track.ErrInvalidStep), "Zero step")
+
+	_, err = tr.ResampleE(-time.Second)
+	isTrue(t, errors.Is(err, track.ErrInvalidStep), "Negative step")
+}
+
+func TestLength(t *testing.T) {
+
+	tr := track.NewTrack(
+		track.NewPoint(at(0), ll.NewLatLong(0., 0.)),
+		track.NewPoint(at(60), ll.NewLatLong(0., 1.)),
+		track.NewPoint(at(120), ll.NewLatLong(1., 1.)),
+	)
+	withinError(t, 2.*ll.NewLatLong(0., 0.).DistanceInNm(ll.NewLatLong(0., 1.)), tr.Length().InNauticalMiles(), 1e-6)
+
+	isTrue(t, track.NewTrack(track.NewPoint(at(0), ll.NewLatLong(0., 0.))).Length().IsZero(), "Single point")
+}
@@ -0,0 +1,121 @@
+package utm
+
+import (
+	"math"
+	ell "stellarsunset/spherical/ellipsoid"
+)
+
+// Transverse Mercator projection of the WGS-84 ellipsoid using Krüger's series to sixth order in the third flattening, which is
+// accurate to a few nanometers within a UTM zone and to millimeters several thousand kilometers from the central meridian.
+//
+// See Karney, "Transverse Mercator with an accuracy of a few nanometers" (2011).
+type transverseMercator struct {
+	a, e, k0 float64
+	// The radius of the rectifying sphere, i.e. a quarter meridian is A * pi / 2
+	A     float64
+	alpha [7]float64
+	beta  [7]float64
+}
+
+func newTransverseMercator(ellipsoid *ell.Ellipsoid, k0 float64) *transverseMercator {
+
+	a, f := ellipsoid.EquatorialRadius().InMeters(), ellipsoid.Flattening()
+	n := f / (2. - f)
+	n2, n3, n4, n5, n6 := n*n, n*n*n, n*n*n*n, n*n*n*n*n, n*n*n*n*n*n
+
+	return &transverseMercator{
+		a:  a,
+		e:  math.Sqrt(f * (2. - f)),
+		k0: k0,
+		A:  a / (1. + n) * (1. + n2/4. + n4/64. + n6/256.),
+		alpha: [7]float64{0.,
+			n/2. - 2.*n2/3. + 5.*n3/16. + 41.*n4/180. - 127.*n5/288. + 7891.*n6/37800.,
+			13.*n2/48. - 3.*n3/5. + 557.*n4/1440. + 281.*n5/630. - 1983433.*n6/1935360.,
+			61.*n3/240. - 103.*n4/140. + 15061.*n5/26880. + 167603.*n6/181440.,
+			49561.*n4/161280. - 179.*n5/168. + 6601661.*n6/7257600.,
+			34729.*n5/80640. - 3418889.*n6/1995840.,
+			212378941. * n6 / 319334400.,
+		},
+		beta: [7]float64{0.,
+			n/2. - 2.*n2/3. + 37.*n3/96. - n4/360. - 81.*n5/512. + 96199.*n6/604800.,
+			n2/48. + n3/15. - 437.*n4/1440. + 46.*n5/105. - 1118711.*n6/3870720.,
+			17.*n3/480. - 37.*n4/840. - 209.*n5/4480. + 5569.*n6/90720.,
+			4397.*n4/161280. - 11.*n5/504. - 830251.*n6/7257600.,
+			4583.*n5/161280. - 108847.*n6/3991680.,
+			20648693. * n6 / 638668800.,
+		},
+	}
+}
+
+// Projects the latitude and longitude relative to the central meridian (both in radians) returning the x (east) and y (north)
+// offsets from the point where the central meridian crosses the equator in meters, along with the meridian convergence in radians
+// (the angle from true north clockwise to grid north) and the point scale factor.
+func (this *transverseMercator) forward(phi, lambda float64) (x, y, gamma, k float64) {
+
+	tau := math.Tan(phi)
+	tauPrime := conformalTan(tau, this.e)
+
+	sinLambda, cosLambda := math.Sincos(lambda)
+
+	xiPrime := math.Atan2(tauPrime, cosLambda)
+	etaPrime := math.Asinh(sinLambda / math.Hypot(tauPrime, cosLambda))
+
+	xi, eta, p, q := xiPrime, etaPrime, 1., 0.
+	for j := 1; j <= 6; j++ {
+		s, c := math.Sincos(2. * float64(j) * xiPrime)
+		sh, ch := math.Sinh(2.*float64(j)*etaPrime), math.Cosh(2.*float64(j)*etaPrime)
+
+		xi += this.alpha[j] * s * ch
+		eta += this.alpha[j] * c * sh
+		p += 2. * float64(j) * this.alpha[j] * c * ch
+		q += 2. * float64(j) * this.alpha[j] * s * sh
+	}
+
+	sinPhi := math.Sin(phi)
+	kPrime := math.Sqrt(1.-this.e*this.e*sinPhi*sinPhi) * math.Sqrt(1.+tau*tau) / math.Hypot(tauPrime, cosLambda)
+	kDoublePrime := this.A / this.a * math.Hypot(p, q)
+	gamma = math.Atan(math.Tan(xiPrime)*math.Tanh(etaPrime)) + math.Atan2(q, p)
+
+	return this.k0 * this.A * eta, this.k0 * this.A * xi, gamma, this.k0 * kPrime * kDoublePrime
+}
+
+// Inverts forward, returning the latitude and longitude relative to the central meridian in radians
+func (this *transverseMercator) inverse(x, y float64) (phi, lambda float64) {
+
+	eta, xi := x/(this.k0*this.A), y/(this.k0*this.A)
+
+	xiPrime, etaPrime := xi, eta
+	for j := 1; j <= 6; j++ {
+		s, c := math.Sincos(2. * float64(j) * xi)
+		xiPrime -= this.beta[j] * s * math.Cosh(2.*float64(j)*eta)
+		etaPrime -= this.beta[j] * c * math.Sinh(2.*float64(j)*eta)
+	}
+
+	sinhEtaPrime := math.Sinh(etaPrime)
+	sinXiPrime, cosXiPrime := math.Sincos(xiPrime)
+
+	tauPrime := sinXiPrime / math.Hypot(sinhEtaPrime, cosXiPrime)
+	return math.Atan(geodeticTan(tauPrime, this.e)), math.Atan2(sinhEtaPrime, cosXiPrime)
+}
+
+// Converts the tangent of a geodetic latitude to the tangent of the corresponding conformal latitude
+func conformalTan(tau, e float64) float64 {
+	sigma := math.Sinh(e * math.Atanh(e*tau/math.Sqrt(1.+tau*tau)))
+	return tau*math.Sqrt(1.+sigma*sigma) - sigma*math.Sqrt(1.+tau*tau)
+}
+
+// Inverts conformalTan with Newton's method, which converges in 2-3 iterations
+func geodeticTan(tauPrime, e float64) float64 {
+
+	e2 := e * e
+	tau := tauPrime
+	for i := 0; i < 10; i++ {
+		tauI := conformalTan(tau, e)
+		delta := (tauPrime - tauI) / math.Sqrt(1.+tauI*tauI) * (1. + (1.-e2)*tau*tau) / ((1. - e2) * math.Sqrt(1.+tau*tau))
+		tau += delta
+		if math.Abs(delta) < 1e-14*math.Max(1., math.Abs(tau)) {
+			break
+		}
+	}
+	return tau
+}
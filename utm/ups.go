@@ -0,0 +1,77 @@
+package utm
+
+import (
+	"math"
+	ell "stellarsunset/spherical/ellipsoid"
+)
+
+// Polar stereographic projection of the WGS-84 ellipsoid, as used by UPS in the polar regions not covered by UTM
+//
+// See Snyder, "Map Projections: A Working Manual" (1987) pp. 160-162.
+type polarStereographic struct {
+	a, e, k0 float64
+	// The ratio of the polar radius of the projection at unit scale to the equatorial radius
+	c float64
+}
+
+func newPolarStereographic(ellipsoid *ell.Ellipsoid, k0 float64) *polarStereographic {
+	a, f := ellipsoid.EquatorialRadius().InMeters(), ellipsoid.Flattening()
+	e := math.Sqrt(f * (2. - f))
+	return &polarStereographic{a, e, k0, 2. / math.Sqrt(math.Pow(1.+e, 1.+e)*math.Pow(1.-e, 1.-e))}
+}
+
+// Projects the latitude and longitude (both in radians) returning the x and y offsets from the pole in meters along with the
+// meridian convergence in radians and the point scale factor. In the north the y axis points along the 180th meridian, in the
+// south along the prime meridian.
+func (this *polarStereographic) forward(north bool, phi, lambda float64) (x, y, gamma, k float64) {
+
+	if !north {
+		phi = -phi
+	}
+
+	// The distance from the pole, written to avoid cancellation close to it
+	tauPrime := conformalTan(math.Tan(phi), this.e)
+	rho := this.a * this.k0 * this.c / (math.Hypot(1., tauPrime) + tauPrime)
+	if phi >= math.Pi/2. {
+		rho = 0.
+	}
+
+	sinLambda, cosLambda := math.Sincos(lambda)
+	// Grid north is along the prime meridian (towards the pole in the north, away from it in the south) so it is rotated from
+	// true north by the longitude
+	x, y, gamma = rho*sinLambda, -rho*cosLambda, lambda
+	if !north {
+		y, gamma = -y, -lambda
+	}
+
+	// The scale factor tends to k0 at the pole where the general expression is indeterminate
+	k = this.k0
+	if sinPhi, cosPhi := math.Sincos(phi); cosPhi > 1e-12 {
+		k = rho * math.Sqrt(1.-this.e*this.e*sinPhi*sinPhi) / (this.a * cosPhi)
+	}
+	return x, y, gamma, k
+}
+
+// Inverts forward, returning the latitude and longitude in radians
+func (this *polarStereographic) inverse(north bool, x, y float64) (phi, lambda float64) {
+
+	if !north {
+		y = -y
+	}
+
+	rho := math.Hypot(x, y)
+	t := rho / (this.a * this.k0 * this.c)
+
+	// Invert t = sqrt(1 + tau'^2) - tau'
+	tauPrime := (1./t - t) / 2.
+	phi = math.Atan(geodeticTan(tauPrime, this.e))
+	if rho == 0. {
+		phi = math.Pi / 2.
+	}
+	lambda = math.Atan2(x, -y)
+
+	if !north {
+		phi = -phi
+	}
+	return phi, lambda
+}
@@ -0,0 +1,64 @@
+package utm_test
+
+import (
+	"math"
+	crs "stellarsunset/spherical/course"
+	dist "stellarsunset/spherical/distance"
+	ell "stellarsunset/spherical/ellipsoid"
+	ll "stellarsunset/spherical/latlong"
+	"stellarsunset/spherical/utm"
+	"testing"
+)
+
+func TestUPSPoles(t *testing.T) {
+
+	north := utm.FromLatLong(ll.Normalized(90., 0.))
+	isTrue(t, north.IsUPS(), "UPS")
+	isEqual(t, utm.Northern, north.Hemisphere())
+	withinError(t, 2000000., north.Easting().InMeters(), 1e-6)
+	withinError(t, 2000000., north.Northing().InMeters(), 1e-6)
+	withinError(t, .994, north.ScaleFactor(), 1e-12)
+	isEqual(t, "Z 2000000 2000000", north.String())
+
+	south := utm.FromLatLong(ll.Normalized(-90., 0.))
+	isEqual(t, utm.Southern, south.Hemisphere())
+	withinError(t, 90., -south.ToLatLong().Latitude(), 1e-12)
+}
+
+func TestUPSAxes(t *testing.T) {
+
+	// In the north grid north points along the 180th meridian, in the south along the prime meridian
+	n := utm.FromLatLong(ll.NewLatLong(85., 179.9999))
+	isTrue(t, n.Northing().InMeters() > 2000000., "North")
+
+	s := utm.FromLatLong(ll.NewLatLong(-85., 0.))
+	isTrue(t, s.Northing().InMeters() > 2000000., "South")
+	withinError(t, 2000000., s.Easting().InMeters(), 1e-6)
+
+	e := utm.FromLatLong(ll.NewLatLong(85., 90.))
+	isTrue(t, e.Easting().InMeters() > 2000000., "East")
+	withinError(t, 2000000., e.Northing().InMeters(), 1e-6)
+}
+
+func TestUPSMeridianDistance(t *testing.T) {
+
+	// Along a meridian the distance from the pole on the grid grows with the scale factor, which is k0 at the pole and ~1 at 81S
+	p := ll.NewLatLong(-81., 30.)
+	c := utm.FromLatLong(p)
+
+	rho := math.Hypot(c.Easting().InMeters()-2000000., c.Northing().InMeters()-2000000.)
+	arc, _, _ := ell.WGS84().InverseInMeters(-90., 30., -81., 30.)
+
+	isTrue(t, .994*arc < rho && rho < c.ScaleFactor()*arc, "Between scale factors")
+}
+
+func TestUPSScale(t *testing.T) {
+
+	p := ll.NewLatLong(86., -120.)
+	q := ell.WGS84().Project(p, crs.OfDegrees(33.), dist.OfMeters(100.))
+
+	cp, cq := utm.FromLatLong(p), utm.FromLatLong(q)
+	grid, err := cp.GridDistanceTo(cq)
+	isTrue(t, err == nil, "Error")
+	withinError(t, 100.*(cp.ScaleFactor()+cq.ScaleFactor())/2., grid.InMeters(), 1e-6)
+}
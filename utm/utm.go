@@ -0,0 +1,259 @@
+/*
+This UTM package converts LatLongs to and from Universal Transverse Mercator (UTM) grid coordinates on the WGS-84 ellipsoid, and
+to Universal Polar Stereographic (UPS) coordinates in the polar regions UTM doesn't cover.
+
+UTM divides the Earth between 80S and 84N into 60 zones six degrees of longitude wide, each with its own transverse Mercator
+projection, with exceptions around southwest Norway and Svalbard. Positions are identified by zone, hemisphere and an easting and
+northing in meters within the zone.
+*/
+package utm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	dist "stellarsunset/spherical/distance"
+	ell "stellarsunset/spherical/ellipsoid"
+	ll "stellarsunset/spherical/latlong"
+)
+
+const (
+	// The zone number used for UPS coordinates
+	UPS int = 0
+
+	utmScale       float64 = 0.9996
+	upsScale       float64 = 0.994
+	falseEasting   float64 = 500000.
+	falseNorthing  float64 = 10000000.
+	upsFalseOrigin float64 = 2000000.
+
+	degreesToRadians float64 = math.Pi / 180.
+	radiansToDegrees float64 = 180. / math.Pi
+)
+
+var (
+	ErrInvalidZone       = errors.New("UTM zone is out of range [0, 60]")
+	ErrZonesDiffer       = errors.New("UTM coordinates are in different zones")
+	ErrInvalidHemisphere = errors.New("UTM hemisphere is invalid")
+)
+
+var (
+	tm = newTransverseMercator(ell.WGS84(), utmScale)
+	ps = newPolarStereographic(ell.WGS84(), upsScale)
+)
+
+type Hemisphere int
+
+const (
+	Northern Hemisphere = iota
+	Southern
+)
+
+var hemispheres = [...]string{
+	Northern: "N",
+	Southern: "S",
+}
+
+func (this Hemisphere) String() string {
+	return hemispheres[this]
+}
+
+// A Coordinate is a position on the UTM grid, or on the UPS grid when its zone is UPS
+type Coordinate struct {
+	zone       int
+	hemisphere Hemisphere
+	easting    float64
+	northing   float64
+}
+
+// Creates a new Coordinate from its zone (1-60 or UPS), hemisphere, easting and northing, panicking if the zone or hemisphere
+// is invalid.
+func NewCoordinate(zone int, hemisphere Hemisphere, easting, northing *dist.Distance) *Coordinate {
+	c, err := TryNewCoordinate(zone, hemisphere, easting, northing)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Creates a new Coordinate from its zone (1-60 or UPS), hemisphere, easting and northing, returning an error matching either
+// ErrInvalidZone or ErrInvalidHemisphere if they are invalid.
+func TryNewCoordinate(zone int, hemisphere Hemisphere, easting, northing *dist.Distance) (*Coordinate, error) {
+	if zone < UPS || 60 < zone {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidZone, zone)
+	}
+	if hemisphere != Northern && hemisphere != Southern {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidHemisphere, hemisphere)
+	}
+	return &Coordinate{zone, hemisphere, easting.InMeters(), northing.InMeters()}, nil
+}
+
+// Converts the LatLong to the UTM grid in its standard zone, or to the UPS grid north of 84N and south of 80S
+func FromLatLong(position *ll.LatLong) *Coordinate {
+	c, _ := FromLatLongInZone(position, StandardZone(position))
+	return c
+}
+
+// Converts the LatLong to the provided UTM zone (or to UPS) regardless of whether it is the position's standard zone, e.g. to
+// keep positions either side of a zone boundary on the same grid. The transverse Mercator projection remains accurate to
+// millimeters several thousand kilometers from the zone's central meridian but its scale distortion grows quickly.
+//
+// Returns ErrInvalidZone if the zone isn't between 1 and 60 or UPS.
+func FromLatLongInZone(position *ll.LatLong, zone int) (*Coordinate, error) {
+	if zone < UPS || 60 < zone {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidZone, zone)
+	}
+
+	hemisphere := Northern
+	if position.Latitude() < 0. {
+		hemisphere = Southern
+	}
+
+	x, y, _, _ := project(position, zone, hemisphere)
+	return &Coordinate{zone, hemisphere, x, y}, nil
+}
+
+// Returns the zone a LatLong is assigned to by default, accounting for the Norway and Svalbard exceptions, or UPS if it is outside
+// the latitudes covered by UTM.
+func StandardZone(position *ll.LatLong) int {
+	lat, lon := position.Latitude(), position.Longitude()
+
+	switch {
+	case lat < -80. || 84. <= lat:
+		return UPS
+	case 56. <= lat && lat < 64. && 3. <= lon && lon < 12.:
+		return 32
+	case 72. <= lat && 0. <= lon && lon < 42.:
+		// Svalbard is covered by the odd zones 31-37, each widened to 9 or 12 degrees
+		return 31 + 2*int(math.Floor((lon+3.)/12.))
+	}
+	return int(math.Floor((lon+180.)/6.))%60 + 1
+}
+
+func (this *Coordinate) Zone() int {
+	return this.zone
+}
+
+func (this *Coordinate) Hemisphere() Hemisphere {
+	return this.hemisphere
+}
+
+func (this *Coordinate) Easting() *dist.Distance {
+	return dist.OfMeters(this.easting)
+}
+
+func (this *Coordinate) Northing() *dist.Distance {
+	return dist.OfMeters(this.northing)
+}
+
+// Returns true if the coordinate is on the UPS grid rather than UTM
+func (this *Coordinate) IsUPS() bool {
+	return this.zone == UPS
+}
+
+// The latitude band letter of the coordinate, C-X (omitting I and O) in 8 degree bands for UTM, or A/B (west/east) in the south
+// and Y/Z in the north for UPS.
+func (this *Coordinate) Band() byte {
+	if this.IsUPS() {
+		bands := "AB"
+		if this.hemisphere == Northern {
+			bands = "YZ"
+		}
+		// Longitudes are ambiguous at the poles so the UPS bands are split by easting instead
+		if this.easting < upsFalseOrigin {
+			return bands[0]
+		}
+		return bands[1]
+	}
+	// Band X is extended north to 84N
+	lat := this.ToLatLong().Latitude()
+	return utmBands[int(math.Max(0., math.Min(19., math.Floor(lat/8.+10.))))]
+}
+
+// Converts the coordinate back to a LatLong
+func (this *Coordinate) ToLatLong() *ll.LatLong {
+
+	north := this.hemisphere == Northern
+
+	if this.IsUPS() {
+		phi, lambda := ps.inverse(north, this.easting-upsFalseOrigin, this.northing-upsFalseOrigin)
+		return ll.Normalized(phi*radiansToDegrees, lambda*radiansToDegrees)
+	}
+
+	y := this.northing
+	if !north {
+		y -= falseNorthing
+	}
+	phi, lambda := tm.inverse(this.easting-falseEasting, y)
+	return ll.Normalized(phi*radiansToDegrees, lambda*radiansToDegrees+centralMeridian(this.zone))
+}
+
+// The point scale factor at the coordinate, i.e. the ratio of distances on the grid to distances on the ellipsoid close to it
+func (this *Coordinate) ScaleFactor() float64 {
+	_, _, _, k := project(this.ToLatLong(), this.zone, this.hemisphere)
+	return k
+}
+
+// The meridian convergence at the coordinate in degrees, i.e. the angle from true north clockwise to grid north. Courses
+// measured from grid north are the true course minus the convergence.
+func (this *Coordinate) Convergence() float64 {
+	_, _, gamma, _ := project(this.ToLatLong(), this.zone, this.hemisphere)
+	return gamma * radiansToDegrees
+}
+
+// Returns the straight line distance between the two coordinates on the grid, or an error matching ErrZonesDiffer if they are in
+// different zones (or hemispheres for UPS). Dividing by the average ScaleFactor along the line approximates the distance on the
+// ellipsoid.
+func (this *Coordinate) GridDistanceTo(that *Coordinate) (*dist.Distance, error) {
+
+	if this.zone != that.zone || (this.IsUPS() && this.hemisphere != that.hemisphere) {
+		return nil, fmt.Errorf("%w: %s and %s", ErrZonesDiffer, this, that)
+	}
+
+	// Southern UTM northings are offset by the false northing, which must be removed before comparing with northern ones
+	dy := this.unfalsedNorthing() - that.unfalsedNorthing()
+	return dist.OfMeters(math.Hypot(this.easting-that.easting, dy)), nil
+}
+
+// Formats the coordinate as zone and band followed by the easting and northing truncated to whole meters, e.g.
+// "18T 583959 4507350" for (40.7128, -74.0060) or "Z 2000000 2000000" for UPS.
+func (this *Coordinate) String() string {
+	prefix := string(this.Band())
+	if !this.IsUPS() {
+		prefix = fmt.Sprintf("%d%c", this.zone, this.Band())
+	}
+	return fmt.Sprintf("%s %.0f %.0f", prefix, math.Floor(this.easting), math.Floor(this.northing))
+}
+
+func (this *Coordinate) unfalsedNorthing() float64 {
+	if !this.IsUPS() && this.hemisphere == Southern {
+		return this.northing - falseNorthing
+	}
+	return this.northing
+}
+
+// Projects the LatLong onto the given zone, returning the easting, northing, meridian convergence (in radians) and scale factor
+func project(position *ll.LatLong, zone int, hemisphere Hemisphere) (easting, northing, gamma, k float64) {
+
+	phi := position.Latitude() * degreesToRadians
+
+	if zone == UPS {
+		x, y, gamma, k := ps.forward(hemisphere == Northern, phi, position.Longitude()*degreesToRadians)
+		return x + upsFalseOrigin, y + upsFalseOrigin, gamma, k
+	}
+
+	lambda := math.Remainder(position.Longitude()-centralMeridian(zone), 360.) * degreesToRadians
+	x, y, gamma, k := tm.forward(phi, lambda)
+
+	if hemisphere == Southern {
+		y += falseNorthing
+	}
+	return x + falseEasting, y, gamma, k
+}
+
+// The longitude in degrees of the central meridian of the UTM zone
+func centralMeridian(zone int) float64 {
+	return float64(zone)*6. - 183.
+}
+
+const utmBands string = "CDEFGHJKLMNPQRSTUVWX"
@@ -0,0 +1,211 @@
+package utm_test
+
+import (
+	"errors"
+	"math"
+	crs "stellarsunset/spherical/course"
+	dist "stellarsunset/spherical/distance"
+	ell "stellarsunset/spherical/ellipsoid"
+	ll "stellarsunset/spherical/latlong"
+	"stellarsunset/spherical/utm"
+	"testing"
+)
+
+func isTrue(t *testing.T, condition bool, s string) {
+	if !condition {
+		t.Error(s)
+	}
+}
+
+func isEqual(t *testing.T, expected, actual any) {
+	if expected != actual {
+		t.Errorf("want = %+v, got = %+v", expected, actual)
+	}
+}
+
+func withinError(t *testing.T, expected, actual, tolerance float64) {
+	if math.Abs(expected-actual) > tolerance {
+		t.Errorf("want = %f, got = %f, tol = %f", expected, actual, tolerance)
+	}
+}
+
+func TestStandardZone(t *testing.T) {
+	isEqual(t, 18, utm.StandardZone(ll.NewLatLong(40.7128, -74.0060)))
+	isEqual(t, 1, utm.StandardZone(ll.NewLatLong(0., -179.9)))
+	isEqual(t, 60, utm.StandardZone(ll.NewLatLong(0., 179.9)))
+
+	// Southwest Norway
+	isEqual(t, 32, utm.StandardZone(ll.NewLatLong(60.39, 5.32)))
+	isEqual(t, 31, utm.StandardZone(ll.NewLatLong(55.9, 2.9)))
+
+	// Svalbard
+	isEqual(t, 31, utm.StandardZone(ll.NewLatLong(78., 8.9)))
+	isEqual(t, 33, utm.StandardZone(ll.NewLatLong(78.22, 15.65)))
+	isEqual(t, 35, utm.StandardZone(ll.NewLatLong(78., 21.)))
+	isEqual(t, 37, utm.StandardZone(ll.NewLatLong(80., 41.9)))
+	isEqual(t, 38, utm.StandardZone(ll.NewLatLong(80., 42.)))
+
+	isEqual(t, utm.UPS, utm.StandardZone(ll.NewLatLong(84., 0.)))
+	isEqual(t, utm.UPS, utm.StandardZone(ll.NewLatLong(-80.1, 0.)))
+	isEqual(t, 31, utm.StandardZone(ll.NewLatLong(-80., 0.)))
+}
+
+func TestFromLatLongReference(t *testing.T) {
+
+	// New York, Sydney and the GeoConvert documentation's example are widely published, the zone exceptions and UPS points are
+	// checked against an independent evaluation of Krüger's series and Snyder's polar stereographic formulas
+	references := []struct {
+		lat, lon          float64
+		s                 string
+		easting, northing float64
+	}{
+		{40.7128, -74.0060, "18T 583959 4507350", 583959.372, 4507350.998},
+		{-33.8688, 151.2093, "56H 334368 6250948", 334368.634, 6250948.345},
+		{33.3, 44.4, "38S 444140 3684706", 444140.545, 3684706.356},
+		{60.39, 5.32, "32V 297230 6700510", 297230.220, 6700510.175},
+		{78., 8.9, "31X 636716 8665261", 636716.846, 8665261.550},
+		{78.22, 15.65, "33X 514813 8683004", 514813.527, 8683004.153},
+		{78., 21., "35X 360973 8665496", 360973.604, 8665496.996},
+		{80., 41.9, "37X 556196 8882986", 556196.057, 8882986.696},
+		{85., 0., "Z 2000000 1444542", 2000000., 1444542.609},
+		{87., -100., "Y 1671916 2057849", 1671916.728, 2057849.933},
+		{-85., 45., "B 2392767 2392767", 2392767.688, 2392767.688},
+	}
+
+	for _, r := range references {
+		c := utm.FromLatLong(ll.NewLatLong(r.lat, r.lon))
+		isEqual(t, r.s, c.String())
+		withinError(t, r.easting, c.Easting().InMeters(), 1e-3)
+		withinError(t, r.northing, c.Northing().InMeters(), 1e-3)
+	}
+}
+
+func TestFromLatLongOrigin(t *testing.T) {
+
+	c := utm.FromLatLong(ll.NewLatLong(0., 3.))
+	isEqual(t, 31, c.Zone())
+	isEqual(t, utm.Northern, c.Hemisphere())
+	withinError(t, 500000., c.Easting().InMeters(), 1e-6)
+	withinError(t, 0., c.Northing().InMeters(), 1e-6)
+	withinError(t, .9996, c.ScaleFactor(), 1e-12)
+	isEqual(t, "31N 500000 0", c.String())
+
+	s := utm.FromLatLong(ll.NewLatLong(-1e-9, 3.))
+	isEqual(t, utm.Southern, s.Hemisphere())
+	withinError(t, 10000000., s.Northing().InMeters(), 1e-3)
+}
+
+func TestFromLatLongCentralMeridian(t *testing.T) {
+
+	// Along the central meridian the northing is the scaled length of the meridian arc from the equator
+	for _, lat := range []float64{-79., -45., -10., 10., 45., 83.} {
+		p := ll.NewLatLong(lat, -75.)
+		arc, _, _ := ell.WGS84().InverseInMeters(0., -75., lat, -75.)
+
+		c := utm.FromLatLong(p)
+		isEqual(t, 18, c.Zone())
+		withinError(t, 500000., c.Easting().InMeters(), 1e-6)
+
+		northing := c.Northing().InMeters()
+		if lat < 0. {
+			northing = 10000000. - northing
+		}
+		withinError(t, .9996*arc, northing, 1e-6)
+	}
+}
+
+func TestFromLatLongScale(t *testing.T) {
+
+	// Short grid distances are the ellipsoidal distance scaled by the point scale factor
+	for _, p := range []*ll.LatLong{ll.NewLatLong(40., -72.1), ll.NewLatLong(-33., 147.5), ll.NewLatLong(70., 2.)} {
+		q := ell.WGS84().Project(p, ll.NewLatLong(0., 0.).CourseTo(ll.NewLatLong(1., 1.)), dist.OfMeters(100.))
+
+		zone := utm.StandardZone(p)
+		cp, _ := utm.FromLatLongInZone(p, zone)
+		cq, _ := utm.FromLatLongInZone(q, zone)
+
+		grid, _ := cp.GridDistanceTo(cq)
+		withinError(t, 100.*(cp.ScaleFactor()+cq.ScaleFactor())/2., grid.InMeters(), 1e-6)
+	}
+}
+
+func TestConvergence(t *testing.T) {
+
+	// A short step due true north is rotated on the grid by minus the convergence, in UTM zones and both UPS grids
+	for _, p := range []*ll.LatLong{ll.NewLatLong(40., -72.1), ll.NewLatLong(-33., 147.5), ll.NewLatLong(87., 45.), ll.NewLatLong(-85., -120.)} {
+		q, _ := ell.WGS84().Direct(p, crs.North(), dist.OfMeters(10.))
+
+		cp, cq := utm.FromLatLong(p), utm.FromLatLong(q)
+		gridCourse := math.Atan2(cq.Easting().InMeters()-cp.Easting().InMeters(), cq.Northing().InMeters()-cp.Northing().InMeters())
+		withinError(t, -cp.Convergence(), gridCourse*180./math.Pi, 1e-4)
+	}
+
+	withinError(t, 0., utm.FromLatLong(ll.NewLatLong(40., -75.)).Convergence(), 1e-12)
+	withinError(t, 45., utm.FromLatLong(ll.NewLatLong(87., 45.)).Convergence(), 1e-9)
+}
+
+func TestRoundTrip(t *testing.T) {
+	for lat := -89.5; lat < 90.; lat += 3.7 {
+		for lon := -179.5; lon < 180.; lon += 4.9 {
+			p := ll.NewLatLong(lat, lon)
+			c := utm.FromLatLong(p)
+
+			back := c.ToLatLong()
+			withinError(t, lat, back.Latitude(), 1e-9)
+			withinError(t, lon, back.Longitude(), 1e-9)
+
+			// And from the easting and northing alone
+			again := utm.NewCoordinate(c.Zone(), c.Hemisphere(), c.Easting(), c.Northing()).ToLatLong()
+			withinError(t, lat, again.Latitude(), 1e-9)
+			withinError(t, lon, again.Longitude(), 1e-9)
+		}
+	}
+}
+
+func TestFromLatLongInZone(t *testing.T) {
+
+	// Just east of the boundary between zones 17 and 18, forced into 17
+	p := ll.NewLatLong(40., -77.9)
+	c, err := utm.FromLatLongInZone(p, 17)
+	isTrue(t, err == nil, "Error")
+	isEqual(t, 17, c.Zone())
+	isTrue(t, c.Easting().InMeters() > 500000.+250000., "Far east of central meridian")
+
+	back := c.ToLatLong()
+	withinError(t, 40., back.Latitude(), 1e-9)
+	withinError(t, -77.9, back.Longitude(), 1e-9)
+
+	_, err = utm.FromLatLongInZone(p, 61)
+	isTrue(t, errors.Is(err, utm.ErrInvalidZone), "Invalid zone")
+}
+
+func TestBand(t *testing.T) {
+	isEqual(t, byte('T'), utm.FromLatLong(ll.NewLatLong(40.7128, -74.0060)).Band())
+	isEqual(t, byte('C'), utm.FromLatLong(ll.NewLatLong(-80., 10.)).Band())
+	isEqual(t, byte('X'), utm.FromLatLong(ll.NewLatLong(83.9, 10.)).Band())
+	isEqual(t, byte('A'), utm.FromLatLong(ll.NewLatLong(-85., -10.)).Band())
+	isEqual(t, byte('B'), utm.FromLatLong(ll.NewLatLong(-85., 10.)).Band())
+	isEqual(t, byte('Y'), utm.FromLatLong(ll.NewLatLong(85., -10.)).Band())
+	isEqual(t, byte('Z'), utm.FromLatLong(ll.NewLatLong(85., 10.)).Band())
+}
+
+func TestGridDistanceTo(t *testing.T) {
+
+	a := utm.NewCoordinate(31, utm.Northern, dist.OfMeters(500000.), dist.OfMeters(3000.))
+	b := utm.NewCoordinate(31, utm.Southern, dist.OfMeters(504000.), dist.OfMeters(10000000.))
+
+	d, err := a.GridDistanceTo(b)
+	isTrue(t, err == nil, "Error")
+	withinError(t, 5000., d.InMeters(), 1e-9)
+
+	_, err = a.GridDistanceTo(utm.NewCoordinate(32, utm.Northern, dist.OfMeters(500000.), dist.OfMeters(0.)))
+	isTrue(t, errors.Is(err, utm.ErrZonesDiffer), "Zones differ")
+}
+
+func TestTryNewCoordinate(t *testing.T) {
+	_, err := utm.TryNewCoordinate(-1, utm.Northern, dist.OfMeters(0.), dist.OfMeters(0.))
+	isTrue(t, errors.Is(err, utm.ErrInvalidZone), "Zone")
+
+	_, err = utm.TryNewCoordinate(1, utm.Hemisphere(2), dist.OfMeters(0.), dist.OfMeters(0.))
+	isTrue(t, errors.Is(err, utm.ErrInvalidHemisphere), "Hemisphere")
+}
@@ -0,0 +1,92 @@
+/*
+This Vector package provides a simple immutable 3D vector for working with n-vectors (unit vectors normal to the surface of the
+Earth) and Earth-Centered Earth-Fixed (ECEF) coordinates.
+
+Many geodesic computations (intersections, means, closest points) are simpler and better conditioned near the poles and the
+antimeridian when performed with vectors rather than with spherical trigonometry on latitudes and longitudes.
+*/
+package vector
+
+import (
+	"math"
+)
+
+type Vec3 struct {
+	x float64
+	y float64
+	z float64
+}
+
+func Zero() *Vec3 {
+	return &Vec3{0., 0., 0.}
+}
+
+func Of(x, y, z float64) *Vec3 {
+	return &Vec3{x, y, z}
+}
+
+func (this *Vec3) X() float64 {
+	return this.x
+}
+
+func (this *Vec3) Y() float64 {
+	return this.y
+}
+
+func (this *Vec3) Z() float64 {
+	return this.z
+}
+
+func (this *Vec3) Plus(that *Vec3) *Vec3 {
+	return Of(this.x+that.x, this.y+that.y, this.z+that.z)
+}
+
+func (this *Vec3) Minus(that *Vec3) *Vec3 {
+	return Of(this.x-that.x, this.y-that.y, this.z-that.z)
+}
+
+func (this *Vec3) Times(scalar float64) *Vec3 {
+	return Of(this.x*scalar, this.y*scalar, this.z*scalar)
+}
+
+func (this *Vec3) Negate() *Vec3 {
+	return Of(-this.x, -this.y, -this.z)
+}
+
+func (this *Vec3) Dot(that *Vec3) float64 {
+	return this.x*that.x + this.y*that.y + this.z*that.z
+}
+
+func (this *Vec3) Cross(that *Vec3) *Vec3 {
+	return Of(this.y*that.z-this.z*that.y, this.z*that.x-this.x*that.z, this.x*that.y-this.y*that.x)
+}
+
+// The Euclidean length of this vector
+func (this *Vec3) Norm() float64 {
+	return math.Sqrt(this.Dot(this))
+}
+
+// Returns a vector of unit length pointing in the same direction as this one, the zero vector is returned unchanged.
+func (this *Vec3) Normalize() *Vec3 {
+	norm := this.Norm()
+	if norm == 0. {
+		return this
+	}
+	return this.Times(1. / norm)
+}
+
+// The angle in radians [0, pi] between this vector and that vector, computed with atan2 so it is accurate for all angles.
+func (this *Vec3) AngleTo(that *Vec3) float64 {
+	return math.Atan2(this.Cross(that).Norm(), this.Dot(that))
+}
+
+// The signed angle in radians [-pi, pi] between this vector and that vector, positive when the rotation from this to that is
+// anticlockwise about the provided reference vector (e.g. a Great Circle normal or an n-vector) by the right hand rule.
+func (this *Vec3) SignedAngleTo(that, reference *Vec3) float64 {
+	cross := this.Cross(that)
+	angle := math.Atan2(cross.Norm(), this.Dot(that))
+	if cross.Dot(reference) < 0. {
+		return -angle
+	}
+	return angle
+}
@@ -0,0 +1,77 @@
+package vector_test
+
+import (
+	"math"
+	vec "stellarsunset/spherical/vector"
+	"testing"
+)
+
+func isEqual(t *testing.T, expected, actual vec.Vec3) {
+	if expected != actual {
+		t.Errorf("want = %+v, got = %+v", expected, actual)
+	}
+}
+
+func withinError(t *testing.T, expected, actual, maxError float64, s string) {
+	if math.Abs(expected-actual) > maxError {
+		t.Errorf("%s: want = %f, got = %f, tol = %f", s, expected, actual, maxError)
+	}
+}
+
+func TestOf(t *testing.T) {
+
+	v := vec.Of(1., 2., 3.)
+
+	withinError(t, 1., v.X(), 0., "X()")
+	withinError(t, 2., v.Y(), 0., "Y()")
+	withinError(t, 3., v.Z(), 0., "Z()")
+}
+
+func TestArithmetic(t *testing.T) {
+
+	one, two := vec.Of(1., 2., 3.), vec.Of(4., 5., 6.)
+
+	isEqual(t, *vec.Of(5., 7., 9.), *one.Plus(two))
+	isEqual(t, *vec.Of(-3., -3., -3.), *one.Minus(two))
+	isEqual(t, *vec.Of(2., 4., 6.), *one.Times(2.))
+	isEqual(t, *vec.Of(-1., -2., -3.), *one.Negate())
+	isEqual(t, *vec.Zero(), *one.Minus(one))
+}
+
+func TestDot(t *testing.T) {
+	withinError(t, 32., vec.Of(1., 2., 3.).Dot(vec.Of(4., 5., 6.)), 0., "Dot()")
+}
+
+func TestCross(t *testing.T) {
+
+	x, y, z := vec.Of(1., 0., 0.), vec.Of(0., 1., 0.), vec.Of(0., 0., 1.)
+
+	isEqual(t, *z, *x.Cross(y))
+	isEqual(t, *x, *y.Cross(z))
+	isEqual(t, *y, *z.Cross(x))
+	isEqual(t, *z.Negate(), *y.Cross(x))
+}
+
+func TestNorm(t *testing.T) {
+
+	withinError(t, 5., vec.Of(3., 4., 0.).Norm(), 0., "Norm()")
+	withinError(t, 1., vec.Of(3., 4., 12.).Normalize().Norm(), 1e-15, "Normalize().Norm()")
+	isEqual(t, *vec.Zero(), *vec.Zero().Normalize())
+}
+
+func TestAngleTo(t *testing.T) {
+
+	x, y := vec.Of(1., 0., 0.), vec.Of(0., 2., 0.)
+
+	withinError(t, math.Pi/2., x.AngleTo(y), 1e-15, "AngleTo(y)")
+	withinError(t, math.Pi, x.AngleTo(x.Negate()), 1e-15, "AngleTo(-x)")
+	withinError(t, 0., x.AngleTo(x.Times(3.)), 1e-15, "AngleTo(3x)")
+}
+
+func TestSignedAngleTo(t *testing.T) {
+
+	x, y, z := vec.Of(1., 0., 0.), vec.Of(0., 1., 0.), vec.Of(0., 0., 1.)
+
+	withinError(t, math.Pi/2., x.SignedAngleTo(y, z), 1e-15, "SignedAngleTo(y, z)")
+	withinError(t, -math.Pi/2., x.SignedAngleTo(y, z.Negate()), 1e-15, "SignedAngleTo(y, -z)")
+}